@@ -0,0 +1,116 @@
+package applejack
+
+import (
+	"math"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+// Checks if the statement returns a value on every path.
+func alwaysReturns(statement node.DynamicNode) bool {
+	switch n := statement.(type) {
+	case *nodes.FunctionReturnNode:
+		return true
+	case *nodes.IfStatementNode:
+		hasElse := false
+		for _, branch := range n.Conditions {
+			if branch.Condition == nil {
+				hasElse = true
+			}
+			if !alwaysReturnsStatements(&branch.StatementsNode) {
+				return false
+			}
+		}
+		return hasElse
+	default:
+		return false
+	}
+}
+
+// Checks if the statements returns a value on every path.
+func alwaysReturnsStatements(statements *nodes.StatementsNode) bool {
+	for _, statement := range statements.Statements {
+		if alwaysReturns(statement) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate a value node that only consists of literals.
+//
+// If the node depends on a variable, a paragraph, or cannot be evaluated safely,
+// the second return value will be false.
+func foldConstant(n node.DynamicNode) (*variable.DynamicVariable, bool) {
+	switch v := n.(type) {
+	case *nodes.LiteralNode:
+		if v.DynamicVariable == nil || v.GetType() == variable.UNKNOWN || v.GetType().IsArray() {
+			return nil, false
+		}
+		return v.DynamicVariable, true
+	case *nodes.BinaryExpressionNode:
+		left, ok := foldConstant(v.Left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := foldConstant(v.Right)
+		if !ok {
+			return nil, false
+		}
+
+		return foldBinary(v.Operator, left, right)
+	default:
+		return nil, false
+	}
+}
+
+func foldBinary(operator nodes.BinaryExpressionOperator, left *variable.DynamicVariable, right *variable.DynamicVariable) (*variable.DynamicVariable, bool) {
+	bothOf := func(t variable.VariableType) bool {
+		return left.GetType() == t && right.GetType() == t
+	}
+
+	switch operator {
+	case nodes.BINARYOPERATOR_EQ:
+		return variable.NewBooleanVariable(left.GetValueString() == right.GetValueString()), true
+	case nodes.BINARYOPERATOR_NEQ:
+		return variable.NewBooleanVariable(left.GetValueString() != right.GetValueString()), true
+	}
+
+	if bothOf(variable.BOOLEAN) {
+		switch operator {
+		case nodes.BINARYOPERATOR_AND:
+			return variable.NewBooleanVariable(left.GetValueBoolean() && right.GetValueBoolean()), true
+		case nodes.BINARYOPERATOR_OR:
+			return variable.NewBooleanVariable(left.GetValueBoolean() || right.GetValueBoolean()), true
+		}
+	}
+
+	if bothOf(variable.NUMBER) {
+		l, r := left.GetValueNumber(), right.GetValueNumber()
+
+		switch operator {
+		case nodes.BINARYOPERATOR_ADD:
+			return variable.NewNumberVariable(l + r), true
+		case nodes.BINARYOPERATOR_SUB:
+			return variable.NewNumberVariable(l - r), true
+		case nodes.BINARYOPERATOR_MUL:
+			return variable.NewNumberVariable(l * r), true
+		case nodes.BINARYOPERATOR_DIV:
+			return variable.NewNumberVariable(l / r), true
+		case nodes.BINARYOPERATOR_MOD:
+			return variable.NewNumberVariable(math.Mod(l, r)), true
+		case nodes.BINARYOPERATOR_GTE:
+			return variable.NewBooleanVariable(l >= r), true
+		case nodes.BINARYOPERATOR_LTE:
+			return variable.NewBooleanVariable(l <= r), true
+		case nodes.BINARYOPERATOR_GT:
+			return variable.NewBooleanVariable(l > r), true
+		case nodes.BINARYOPERATOR_LT:
+			return variable.NewBooleanVariable(l < r), true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,358 @@
+package applejack
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/spike"
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// An Issue is a problem found by the linter.
+type Issue struct {
+	node.Node
+
+	Rule    Rule
+	Message string
+
+	// 1-based line number of the issue
+	Line int
+	// 1-based column number of the issue
+	Column int
+	// 1-based line number right after the end of the issue
+	EndLine int
+	// 1-based column number right after the end of the issue
+	EndColumn int
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", i.Line, i.Column, i.Message, i.Rule)
+}
+
+type symbol struct {
+	node.Node
+
+	Name     string
+	Constant bool
+
+	// Parameters and loop variables are not reported when unused
+	Implicit bool
+
+	Read bool
+}
+
+type linter struct {
+	config Config
+
+	report *nodes.ReportNode
+	issues []Issue
+
+	scopes [][]*symbol
+
+	paragraphs    []*nodes.FunctionNode
+	paragraphUses map[string]bool
+	current       *nodes.FunctionNode
+}
+
+func (l *linter) addIssue(rule Rule, n node.Node, format string, a ...any) {
+	if !l.config.IsEnabled(rule) {
+		return
+	}
+
+	l.issues = append(l.issues, Issue{
+		Node:    n,
+		Rule:    rule,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (l *linter) pushScope() {
+	l.scopes = append(l.scopes, make([]*symbol, 0))
+}
+func (l *linter) popScope() {
+	scope := l.scopes[len(l.scopes)-1]
+	l.scopes = l.scopes[:len(l.scopes)-1]
+
+	for _, s := range scope {
+		if s.Read || s.Implicit {
+			continue
+		}
+
+		if s.Constant {
+			l.addIssue(RULE_UNUSED_CONSTANT, s.Node, "Constant variable '%s' is never read", s.Name)
+		} else {
+			l.addIssue(RULE_UNUSED_VARIABLE, s.Node, "Variable '%s' is never read", s.Name)
+		}
+	}
+}
+func (l *linter) declare(s *symbol) {
+	l.scopes[len(l.scopes)-1] = append(l.scopes[len(l.scopes)-1], s)
+}
+func (l *linter) lookup(name string) *symbol {
+	for idx := len(l.scopes) - 1; idx >= 0; idx-- {
+		for _, s := range l.scopes[idx] {
+			if s.Name == name {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+func (l *linter) useParagraph(name string) bool {
+	if !slices.ContainsFunc(l.paragraphs, func(p *nodes.FunctionNode) bool { return p.Name == name }) {
+		return false
+	}
+
+	if l.current == nil || l.current.Name != name {
+		l.paragraphUses[name] = true
+	}
+	return true
+}
+
+func (l *linter) readIdentifier(name string) {
+	if s := l.lookup(name); s != nil {
+		s.Read = true
+		return
+	}
+
+	l.useParagraph(name)
+}
+
+func (l *linter) visitValue(n node.DynamicNode) {
+	switch v := n.(type) {
+	case *nodes.LiteralDictionaryNode:
+		for _, value := range v.Values {
+			l.visitValue(value)
+		}
+	case *nodes.IdentifierNode:
+		l.readIdentifier(v.Identifier)
+	case *nodes.DictionaryIdentifierNode:
+		l.readIdentifier(v.Identifier)
+		l.visitValue(v.Index)
+	case *nodes.FunctionCallNode:
+		l.useParagraph(v.Identifier)
+		for _, parameter := range v.Parameters {
+			l.visitValue(parameter)
+		}
+	case *nodes.BinaryExpressionNode:
+		l.visitValue(v.Left)
+		l.visitValue(v.Right)
+	}
+}
+
+func (l *linter) checkCondition(condition node.DynamicNode) {
+	value, ok := foldConstant(condition)
+	if !ok || value.GetType() != variable.BOOLEAN {
+		return
+	}
+
+	l.addIssue(RULE_CONSTANT_CONDITION, condition.ToNode(), "Condition is always %t", value.GetValueBoolean())
+}
+
+func (l *linter) checkLoopVariable(n node.Node, name string) {
+	if l.lookup(name) == nil {
+		return
+	}
+
+	l.addIssue(RULE_SHADOWED_LOOP_VARIABLE, n, "Loop variable '%s' shadows an existing variable", name)
+}
+
+func (l *linter) visitStatements(statements *nodes.StatementsNode, declarations ...*symbol) {
+	l.pushScope()
+	for _, s := range declarations {
+		l.declare(s)
+	}
+
+	reportedUnreachable := false
+	for idx, statement := range statements.Statements {
+		if !reportedUnreachable && idx > 0 && alwaysReturns(statements.Statements[idx-1]) {
+			l.addIssue(RULE_UNREACHABLE_CODE, statement.ToNode(), "Unreachable statement")
+			reportedUnreachable = true
+		}
+
+		l.visitStatement(statement)
+	}
+
+	l.popScope()
+}
+
+func (l *linter) visitStatement(statement node.DynamicNode) {
+	switch n := statement.(type) {
+	case *nodes.PrintNode:
+		l.visitValue(n.Value)
+	case *nodes.PromptNode:
+		l.visitValue(n.Prompt)
+	case *nodes.FileReadNode:
+		l.visitValue(n.Path)
+	case *nodes.FileReadLinesNode:
+		l.visitValue(n.Path)
+	case *nodes.FileWriteNode:
+		l.visitValue(n.Value)
+		l.visitValue(n.Path)
+	case *nodes.EnvironmentReadNode:
+		l.visitValue(n.Name)
+	case *nodes.VariableDeclarationNode:
+		l.visitValue(n.Value)
+		l.declare(&symbol{Node: n.Node, Name: n.Identifier, Constant: n.Constant})
+	case *nodes.VariableModifyNode:
+		l.visitValue(n.Value)
+	case *nodes.ArrayModifyNode:
+		l.visitValue(n.Index)
+		l.visitValue(n.Value)
+	case *nodes.UnaryExpressionNode:
+		if identifier, ok := n.Identifier.(*nodes.DictionaryIdentifierNode); ok {
+			l.visitValue(identifier.Index)
+		}
+	case *nodes.FunctionCallNode:
+		l.visitValue(n)
+	case *nodes.FunctionReturnNode:
+		l.visitValue(n.Value)
+	case *nodes.AssertNode:
+		l.visitValue(n.Condition)
+	case *nodes.IfStatementNode:
+		for _, branch := range n.Conditions {
+			if branch.Condition != nil {
+				l.visitValue(*branch.Condition)
+				l.checkCondition(*branch.Condition)
+			}
+			l.visitStatements(&branch.StatementsNode)
+		}
+	case *nodes.WhileStatementNode:
+		l.visitValue(*n.Condition)
+		l.checkCondition(*n.Condition)
+		l.visitStatements(&n.StatementsNode)
+	case *nodes.ForEveryArrayStatementNode:
+		l.readIdentifier(n.Identifier)
+		l.checkLoopVariable(n.ToNode(), n.VariableName)
+		l.visitStatements(&n.StatementsNode, &symbol{Node: n.ToNode(), Name: n.VariableName, Implicit: true})
+	case *nodes.ForEveryRangeStatementNode:
+		l.visitValue(n.RangeStart)
+		l.visitValue(n.RangeEnd)
+		l.checkLoopVariable(n.ToNode(), n.VariableName)
+		l.visitStatements(&n.StatementsNode, &symbol{Node: n.ToNode(), Name: n.VariableName, Implicit: true})
+	}
+}
+
+func (l *linter) visitParagraph(paragraph *nodes.FunctionNode) {
+	l.current = paragraph
+
+	parameters := make([]*symbol, 0, len(paragraph.Parameters))
+	for _, parameter := range paragraph.Parameters {
+		parameters = append(parameters, &symbol{Node: paragraph.Node, Name: parameter.Name, Implicit: true})
+	}
+
+	l.visitStatements(paragraph.Body, parameters...)
+
+	if paragraph.ReturnType != variable.UNKNOWN && !alwaysReturnsStatements(paragraph.Body) {
+		l.addIssue(RULE_MISSING_RETURN, paragraph.Node, "Paragraph '%s' does not return a value on every path", paragraph.Name)
+	}
+
+	l.current = nil
+}
+
+func (l *linter) lint() {
+	for _, n := range l.report.Body {
+		if paragraph, ok := n.(*nodes.FunctionNode); ok {
+			l.paragraphs = append(l.paragraphs, paragraph)
+		}
+	}
+
+	// Every global is declared before any paragraph is run, wherever it is in the report
+	l.pushScope()
+	for _, n := range l.report.Body {
+		if n, ok := n.(*nodes.VariableDeclarationNode); ok {
+			l.visitValue(n.Value)
+			l.declare(&symbol{Node: n.Node, Name: n.Identifier, Constant: n.Constant})
+		}
+	}
+	for _, paragraph := range l.paragraphs {
+		l.visitParagraph(paragraph)
+	}
+	l.popScope()
+
+	for _, paragraph := range l.paragraphs {
+		if paragraph.Main || l.paragraphUses[paragraph.Name] || paragraph.IsTest() {
+			continue
+		}
+		l.addIssue(RULE_UNUSED_PARAGRAPH, paragraph.Node, "Paragraph '%s' is never called", paragraph.Name)
+	}
+}
+
+// Lint the report, and return the issues that were found.
+//
+// An issue can be suppressed with a '(lint:ignore)' comment on the same line,
+// or only for specific rules with '(lint:ignore rule-a, rule-b)'.
+func Lint(source string, config Config) ([]Issue, error) {
+	report, err := spike.CreateReport(twilight.Parse(source), source)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{
+		config:        config,
+		report:        report,
+		issues:        make([]Issue, 0),
+		scopes:        make([][]*symbol, 0),
+		paragraphs:    make([]*nodes.FunctionNode, 0),
+		paragraphUses: make(map[string]bool),
+	}
+	l.lint()
+
+	file := luna.NewSourceFile(source)
+	suppressions := findSuppressions(file)
+
+	issues := make([]Issue, 0, len(l.issues))
+	for _, issue := range l.issues {
+		issueRange := issue.Range(file)
+		issue.Line, issue.Column = issueRange.Start.Line, issueRange.Start.Column
+		issue.EndLine, issue.EndColumn = issueRange.End.Line, issueRange.End.Column
+
+		if rules, ok := suppressions[issue.Line]; ok && (len(rules) == 0 || slices.Contains(rules, issue.Rule)) {
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+
+	sort.SliceStable(issues, func(a, b int) bool { return issues[a].Start < issues[b].Start })
+
+	return issues, nil
+}
+
+// Collect the '(lint:ignore ...)' comments of each line.
+func findSuppressions(file *luna.SourceFile) map[int][]Rule {
+	const Directive = "lint:ignore"
+
+	suppressions := make(map[int][]Rule)
+
+	for _, t := range twilight.ParseWithTrivia(file.Content) {
+		if t.Type != token.TokenType_CommentParen {
+			continue
+		}
+
+		content := strings.TrimSpace(t.Value[1 : len(t.Value)-1])
+		if !strings.HasPrefix(content, Directive) {
+			continue
+		}
+
+		rules := make([]Rule, 0)
+		for _, rule := range strings.Split(strings.TrimPrefix(content, Directive), ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				rules = append(rules, Rule(rule))
+			}
+		}
+
+		suppressions[file.Position(t.Start).Line] = rules
+	}
+
+	return suppressions
+}
@@ -0,0 +1,216 @@
+package applejack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lintRules(t *testing.T, source string, config Config) []Rule {
+	issues, err := Lint(source, config)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	rules := make([]Rule, 0, len(issues))
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	return rules
+}
+
+func TestLint(t *testing.T) {
+	t.Run("should report unused variables and constants", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		Today I learned how to lint!
+			Did you know that Spike is the number 1?
+			Did you know that Rarity is always the word "Gem"?
+			Did you know that Applejack is the number 2?
+			I said Applejack!
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		issues, err := Lint(source, DefaultConfig())
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.Len(t, issues, 2) {
+			return
+		}
+
+		assert.Equal(t, RULE_UNUSED_VARIABLE, issues[0].Rule)
+		assert.Equal(t, 3, issues[0].Line)
+		assert.Equal(t, RULE_UNUSED_CONSTANT, issues[1].Rule)
+		assert.Equal(t, 4, issues[1].Line)
+	})
+
+	t.Run("should read globals that are declared after the paragraphs", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		Today I learned how to lint!
+			I said Spike!
+		That's all about how to lint.
+		Did you know that Spike is the number 1?
+		Your faithful student, Applejack.`
+
+		assert.Empty(t, lintRules(t, source, DefaultConfig()))
+	})
+
+	t.Run("should report unused paragraphs", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		I learned how to help!
+			I remembered how to help.
+		That's all about how to help.
+		I learned how to assist!
+			I said "Assist"!
+		That's all about how to assist.
+		Today I learned how to lint!
+			I remembered how to assist.
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		assert.Equal(t, []Rule{RULE_UNUSED_PARAGRAPH}, lintRules(t, source, DefaultConfig()))
+	})
+
+	t.Run("should not report test paragraphs", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		I learned how to test linting!
+			I said "Tested"!
+		That's all about how to test linting.
+		Today I learned how to lint!
+			I said "Linted"!
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		assert.Empty(t, lintRules(t, source, DefaultConfig()))
+	})
+
+	t.Run("should report unreachable statements and missing returns", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		I learned how to count to get a number!
+			Then you get 1!
+			I said "Never"!
+		That's all about how to count.
+		I learned how to guess using the number Spike to get a number!
+			If Spike is 1,
+				Then you get 1!
+			That's what I would do.
+		That's all about how to guess.
+		Today I learned how to lint!
+			I said how to count!
+			I said how to guess using 1!
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		assert.Equal(t, []Rule{RULE_UNREACHABLE_CODE, RULE_MISSING_RETURN}, lintRules(t, source, DefaultConfig()))
+	})
+
+	t.Run("should accept returns on every branch", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		I learned how to guess using the number Spike to get a number!
+			If Spike is 1,
+				Then you get 1!
+			Otherwise,
+				Then you get 2!
+			That's what I would do.
+		That's all about how to guess.
+		Today I learned how to lint!
+			I said how to guess using 1!
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		assert.Empty(t, lintRules(t, source, DefaultConfig()))
+	})
+
+	t.Run("should report constant conditions", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		Today I learned how to lint!
+			Did you know that Spike is the number 1?
+			If 1 is greater than 2,
+				I said "Never"!
+			That's what I would do.
+			If Spike is 1,
+				I said "Maybe"!
+			That's what I would do.
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		issues, err := Lint(source, DefaultConfig())
+		if !assert.NoError(t, err) || !assert.Len(t, issues, 1) {
+			return
+		}
+		assert.Equal(t, RULE_CONSTANT_CONDITION, issues[0].Rule)
+		assert.Equal(t, "Condition is always false", issues[0].Message)
+	})
+
+	t.Run("should report shadowed loop variables", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		Did you know that Spike is the number 1?
+		Today I learned how to lint!
+			I said Spike!
+			For every number Spike from 1 to 3,
+				I said Spike!
+			That's what I did.
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		assert.Equal(t, []Rule{RULE_SHADOWED_LOOP_VARIABLE}, lintRules(t, source, DefaultConfig()))
+	})
+
+	t.Run("should skip disabled rules", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		Today I learned how to lint!
+			Did you know that Spike is the number 1?
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		config := DefaultConfig()
+		config.Disable(RULE_UNUSED_VARIABLE)
+
+		assert.Empty(t, lintRules(t, source, config))
+	})
+
+	t.Run("should skip suppressed lines", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		Today I learned how to lint!
+			Did you know that Spike is the number 1? (lint:ignore)
+			Did you know that Rarity is the number 1? (lint:ignore unused-variable)
+			Did you know that Applejack is the number 1? (lint:ignore unused-constant)
+		That's all about how to lint.
+		Your faithful student, Applejack.`
+
+		issues, err := Lint(source, DefaultConfig())
+		if !assert.NoError(t, err) || !assert.Len(t, issues, 1) {
+			return
+		}
+		assert.Equal(t, 5, issues[0].Line)
+	})
+
+	t.Run("should error on an invalid report", func(t *testing.T) {
+		source := `Dear Princess Celestia: Linting!
+		Today I learned how to lint!`
+
+		_, err := Lint(source, DefaultConfig())
+		assert.Error(t, err)
+	})
+}
+
+func TestLintSamples(t *testing.T) {
+	samples, err := filepath.Glob(filepath.Join("..", "samples", "*.fim"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, sample := range samples {
+		t.Run(filepath.Base(sample), func(t *testing.T) {
+			source, err := os.ReadFile(sample)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, err = Lint(string(source), DefaultConfig())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package applejack
+
+type Rule string
+
+const (
+	RULE_UNUSED_VARIABLE        Rule = "unused-variable"
+	RULE_UNUSED_CONSTANT        Rule = "unused-constant"
+	RULE_UNUSED_PARAGRAPH       Rule = "unused-paragraph"
+	RULE_UNREACHABLE_CODE       Rule = "unreachable-code"
+	RULE_MISSING_RETURN         Rule = "missing-return"
+	RULE_CONSTANT_CONDITION     Rule = "constant-condition"
+	RULE_SHADOWED_LOOP_VARIABLE Rule = "shadowed-loop-variable"
+)
+
+// Every rule that the linter knows of.
+var Rules = []Rule{
+	RULE_UNUSED_VARIABLE,
+	RULE_UNUSED_CONSTANT,
+	RULE_UNUSED_PARAGRAPH,
+	RULE_UNREACHABLE_CODE,
+	RULE_MISSING_RETURN,
+	RULE_CONSTANT_CONDITION,
+	RULE_SHADOWED_LOOP_VARIABLE,
+}
+
+var ruleDescriptions = map[Rule]string{
+	RULE_UNUSED_VARIABLE:        "Variables that are declared but never read",
+	RULE_UNUSED_CONSTANT:        "Constant variables that are declared but never read",
+	RULE_UNUSED_PARAGRAPH:       "Paragraphs that are never called",
+	RULE_UNREACHABLE_CODE:       "Statements that can never run",
+	RULE_MISSING_RETURN:         "Paragraphs with a return type that do not return on every path",
+	RULE_CONSTANT_CONDITION:     "Conditions that are always true or always false",
+	RULE_SHADOWED_LOOP_VARIABLE: "Loop variables that shadow an existing variable",
+}
+
+func (r Rule) Description() string {
+	return ruleDescriptions[r]
+}
+
+// Checks if the rule is known by the linter.
+func (r Rule) IsValid() bool {
+	_, ok := ruleDescriptions[r]
+	return ok
+}
+
+// The Config decides which rules are checked.
+type Config struct {
+	Rules map[Rule]bool
+}
+
+// Create a Config with every rule enabled.
+func DefaultConfig() Config {
+	config := Config{
+		Rules: make(map[Rule]bool, len(Rules)),
+	}
+	for _, rule := range Rules {
+		config.Rules[rule] = true
+	}
+	return config
+}
+
+func (c Config) Enable(rule Rule) {
+	c.Rules[rule] = true
+}
+func (c Config) Disable(rule Rule) {
+	c.Rules[rule] = false
+}
+func (c Config) IsEnabled(rule Rule) bool {
+	return c.Rules[rule]
+}
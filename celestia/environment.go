@@ -0,0 +1,111 @@
+package celestia
+
+import (
+	"fmt"
+	"slices"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+// Name of the predefined global STRING_ARRAY which holds the report's arguments
+const ARGUMENTS_VARIABLE = "Arguments"
+
+// Declare the predefined global variables, unless the report declares them itself.
+//
+// A variable that is declared by any paragraph, including its parameters and
+// loop variables, is not predefined either, so that the paragraph can use it.
+func (i *Interpreter) declarePredefinedVariables() {
+	declared := i.Variables.Has(ARGUMENTS_VARIABLE, true)
+	for _, paragraph := range i.Paragraphs {
+		if declared {
+			break
+		}
+
+		declared = slices.ContainsFunc(paragraph.FunctionNode.Parameters, func(p nodes.FunctionNodeParameter) bool {
+			return p.Name == ARGUMENTS_VARIABLE
+		}) || declaresVariable(paragraph.FunctionNode.Body, ARGUMENTS_VARIABLE)
+	}
+
+	if !declared {
+		i.arguments = variable.NewDictionaryVariable(variable.STRING_ARRAY)
+		i.Variables.PushVariable(&Variable{
+			Name:            ARGUMENTS_VARIABLE,
+			DynamicVariable: i.arguments,
+			Constant:        true,
+		}, true)
+	}
+}
+
+// Checks if the statements, or the body of any of them, declare a variable with the name.
+func declaresVariable(statements *nodes.StatementsNode, name string) bool {
+	for _, statement := range statements.Statements {
+		switch n := statement.(type) {
+		case *nodes.VariableDeclarationNode:
+			if n.Identifier == name {
+				return true
+			}
+		case *nodes.IfStatementNode:
+			for _, branch := range n.Conditions {
+				if declaresVariable(&branch.StatementsNode, name) {
+					return true
+				}
+			}
+		case *nodes.WhileStatementNode:
+			if declaresVariable(&n.StatementsNode, name) {
+				return true
+			}
+		case *nodes.ForEveryArrayStatementNode:
+			if n.VariableName == name || declaresVariable(&n.StatementsNode, name) {
+				return true
+			}
+		case *nodes.ForEveryRangeStatementNode:
+			if n.VariableName == name || declaresVariable(&n.StatementsNode, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Set the values of the predefined Arguments array, starting from 1.
+//
+// The arguments are kept when the interpreter is Reset. If the report
+// declares its own Arguments variable, nothing is changed.
+func (i *Interpreter) SetArguments(arguments []string) {
+	if i.arguments == nil {
+		return
+	}
+
+	dictionary := i.arguments.GetValueDictionary()
+	clear(dictionary)
+	for idx, argument := range arguments {
+		dictionary[idx+1] = variable.NewRawStringVariable(argument)
+	}
+
+	i.initial = i.Variables.Snapshot()
+}
+
+func (i *Interpreter) evaluateEnvironmentReadNode(n *nodes.EnvironmentReadNode) error {
+	v, err := i.getReadVariable(n, n.Identifier, variable.STRING)
+	if err != nil {
+		return err
+	}
+
+	name, err := i.EvaluateValueNode(n.Name, true)
+	if err != nil {
+		return err
+	}
+	if name.GetType() != variable.STRING {
+		return n.Name.ToNode().CreateError(fmt.Sprintf("Expected environment variable name to be of type %s, got %s", variable.STRING, name.GetType()), i.source)
+	}
+
+	value, ok := i.Environment[name.GetValueString()]
+	if !ok {
+		return n.ToNode().CreateError(fmt.Sprintf("Environment variable '%s' is not allowed", name.GetValueString()), i.source)
+	}
+
+	v.SetValueString(value)
+	return nil
+}
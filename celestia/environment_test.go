@@ -0,0 +1,77 @@
+package celestia
+
+import (
+	"testing"
+)
+
+func TestEnvironment(t *testing.T) {
+	t.Run("should read the arguments", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Arguments!
+			Today I learned how to echo!
+			For every word Argument in Arguments,
+				I said Argument!
+			That's what I did.
+			That's all about how to echo.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "Apple\nJack\n", Arguments: []string{"Apple", "Jack"}})
+	})
+	t.Run("should keep the report's own arguments variable", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Arguments!
+			Did you know that Arguments is the phrase "Mine"?
+			Today I learned how to echo!
+			I said Arguments!
+			That's all about how to echo.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "Mine\n", Arguments: []string{"Apple"}})
+	})
+	t.Run("should let a paragraph declare its own arguments variable", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Arguments!
+			I learned how to count!
+				Did you know that Arguments is the number 3?
+				I said Arguments!
+			That's all about how to count.
+			I learned how to list using the word Arguments!
+				I said Arguments!
+			That's all about how to list.
+			Today I learned how to echo!
+				I remembered how to count.
+				I remembered how to list using "Gala".
+			That's all about how to echo.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "3\nGala\n", Arguments: []string{"Apple"}})
+	})
+	t.Run("should read an allowed environment variable", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Environment!
+			Today I learned how to read the environment!
+			Did you know that Home is a word?
+			I fetched Home from "HOME".
+			I said Home!
+			That's all about how to read the environment.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "/home/twilight\n", Environment: map[string]string{"HOME": "/home/twilight"}})
+	})
+	t.Run("should not read an environment variable that is not allowed", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Environment!
+			Today I learned how to read the environment!
+			Did you know that Home is a word?
+			I fetched Home from "HOME".
+			That's all about how to read the environment.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Error: true})
+	})
+}
@@ -0,0 +1,164 @@
+package celestia
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+// Returned by the file statements when the interpreter has no sandbox directory
+var ErrFileAccessDisabled = errors.New("File access is disabled")
+
+// Open the sandbox directory. Every path is resolved against it, and cannot
+// escape it, even through symbolic links.
+func (i *Interpreter) openSandbox() (*os.Root, error) {
+	if i.Sandbox == "" {
+		return nil, ErrFileAccessDisabled
+	}
+
+	return os.OpenRoot(i.Sandbox)
+}
+
+// Evaluate the path of a file statement.
+func (i *Interpreter) evaluateFilePath(n node.DynamicNode) (string, error) {
+	path, err := i.EvaluateValueNode(n, true)
+	if err != nil {
+		return "", err
+	}
+	if path.GetType() != variable.STRING {
+		return "", n.ToNode().CreateError(fmt.Sprintf("Expected path to be of type %s, got %s", variable.STRING, path.GetType()), i.source)
+	}
+
+	return path.GetValueString(), nil
+}
+
+// Get the variable that a file or environment statement reads into.
+func (i *Interpreter) getReadVariable(n node.DynamicNode, identifier string, expected variable.VariableType) (*Variable, error) {
+	if !i.Variables.Has(identifier, true) {
+		return nil, n.ToNode().CreateError(i.variableNotFound(identifier), i.source)
+	}
+
+	v := i.Variables.Get(identifier, true)
+	if v.Constant {
+		return nil, n.ToNode().CreateError("Cannot modify a constant variable.", i.source)
+	}
+	if v.GetType() != expected {
+		return nil, n.ToNode().CreateError(fmt.Sprintf("Expected variable to be of type %s, got %s", expected, v.GetType()), i.source)
+	}
+
+	return v, nil
+}
+
+func (i *Interpreter) readFile(n node.DynamicNode, pathNode node.DynamicNode) (string, error) {
+	path, err := i.evaluateFilePath(pathNode)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := i.openSandbox()
+	if err != nil {
+		return "", n.ToNode().CreateError(err.Error(), i.source)
+	}
+	defer root.Close()
+
+	file, err := root.Open(path)
+	if err != nil {
+		return "", n.ToNode().CreateError(fmt.Sprintf("Could not read file '%s': %s", path, err), i.source)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", n.ToNode().CreateError(fmt.Sprintf("Could not read file '%s': %s", path, err), i.source)
+	}
+
+	return string(data), nil
+}
+
+func (i *Interpreter) evaluateFileReadNode(n *nodes.FileReadNode) error {
+	v, err := i.getReadVariable(n, n.Identifier, variable.STRING)
+	if err != nil {
+		return err
+	}
+
+	content, err := i.readFile(n, n.Path)
+	if err != nil {
+		return err
+	}
+
+	v.SetValueString(content)
+	return nil
+}
+
+func (i *Interpreter) evaluateFileReadLinesNode(n *nodes.FileReadLinesNode) error {
+	v, err := i.getReadVariable(n, n.Identifier, variable.STRING_ARRAY)
+	if err != nil {
+		return err
+	}
+
+	content, err := i.readFile(n, n.Path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	// The array is modified in place, as it might be shared with another variable
+	dictionary := v.GetValueDictionary()
+	clear(dictionary)
+	for idx, line := range lines {
+		dictionary[idx+1] = variable.NewRawStringVariable(strings.TrimRight(line, "\r\n"))
+	}
+
+	return nil
+}
+
+func (i *Interpreter) evaluateFileWriteNode(n *nodes.FileWriteNode) error {
+	value, err := i.EvaluateValueNode(n.Value, true)
+	if err != nil {
+		return err
+	}
+	if value.GetType().IsArray() {
+		return n.Value.ToNode().CreateError("Cannot write an array value", i.source)
+	}
+
+	path, err := i.evaluateFilePath(n.Path)
+	if err != nil {
+		return err
+	}
+
+	root, err := i.openSandbox()
+	if err != nil {
+		return n.ToNode().CreateError(err.Error(), i.source)
+	}
+	defer root.Close()
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if n.Append {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+
+	file, err := root.OpenFile(path, flags, 0644)
+	if err != nil {
+		return n.ToNode().CreateError(fmt.Sprintf("Could not write file '%s': %s", path, err), i.source)
+	}
+
+	_, err = file.WriteString(value.GetValueString())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n.ToNode().CreateError(fmt.Sprintf("Could not write file '%s': %s", path, err), i.source)
+	}
+
+	return nil
+}
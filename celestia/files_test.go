@@ -0,0 +1,112 @@
+package celestia
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiles(t *testing.T) {
+	t.Run("should read a file", func(t *testing.T) {
+		sandbox := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(sandbox, "story.txt"), []byte("Once upon a time\r\nThe end"), 0644))
+
+		source :=
+			`Dear Princess Celestia: Files!
+			Today I learned how to read a file!
+			Did you know that Story is a word?
+			I studied Story from "story.txt".
+			I said Story!
+			That's all about how to read a file.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "Once upon a time\r\nThe end\n", Sandbox: sandbox})
+	})
+	t.Run("should read every line of a file", func(t *testing.T) {
+		sandbox := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(sandbox, "story.txt"), []byte("Once upon a time\r\n\nThe end\n"), 0644))
+
+		source :=
+			`Dear Princess Celestia: Files!
+			Today I learned how to read every line!
+			Did you know that Lines has many words?
+			I studied every line of Lines from "story.txt".
+			For every word Line in Lines,
+				I said "> " plus Line!
+			That's what I did.
+			That's all about how to read every line.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "> Once upon a time\n> \n> The end\n", Sandbox: sandbox})
+	})
+	t.Run("should write and append to a file", func(t *testing.T) {
+		sandbox := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(sandbox, "story.txt"), []byte("Overwritten"), 0644))
+
+		source :=
+			`Dear Princess Celestia: Files!
+			Today I learned how to write a file!
+			Did you know that Path is the phrase "story.txt"?
+			I penned "Once upon a time" in Path.
+			I appended " there was a pony" to Path.
+			That's all about how to write a file.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Sandbox: sandbox})
+
+		content, err := os.ReadFile(filepath.Join(sandbox, "story.txt"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Once upon a time there was a pony", string(content))
+	})
+	t.Run("should not access files without a sandbox", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Files!
+			Today I learned how to write a file!
+			I penned "Hello" in "story.txt".
+			That's all about how to write a file.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Error: true})
+	})
+	t.Run("should not escape the sandbox", func(t *testing.T) {
+		parent := t.TempDir()
+		sandbox := filepath.Join(parent, "sandbox")
+		assert.NoError(t, os.Mkdir(sandbox, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(parent, "secret.txt"), []byte("Secret"), 0644))
+
+		source :=
+			`Dear Princess Celestia: Files!
+			Today I learned how to read a file!
+			Did you know that Story is a word?
+			I studied Story from "../secret.txt".
+			I said Story!
+			That's all about how to read a file.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Error: true, Sandbox: sandbox})
+	})
+	t.Run("should error on a mismatched variable type", func(t *testing.T) {
+		sandbox := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(sandbox, "story.txt"), []byte("The end"), 0644))
+
+		source :=
+			`Dear Princess Celestia: Files!
+			Today I learned how to read a file!
+			Did you know that Story is a number?
+			I studied Story from "story.txt".
+			That's all about how to read a file.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Error: true, Sandbox: sandbox})
+	})
+}
@@ -0,0 +1,131 @@
+package celestia
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"git.jaezmien.com/Jaezmien/fim/luna/stack"
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+// Returned when resuming a run with frames that do not match the report
+var ErrInvalidFrames = errors.New("Frames do not match the report")
+
+// Returned when taking the state of a run inside a paragraph that was called
+// from a value, as the rest of that value cannot be resumed.
+var ErrNotResumable = errors.New("Cannot suspend inside a paragraph that is called from a value")
+
+// A Frame is the position of a run inside a block of statements.
+type Frame struct {
+	// Index of the statement that is being run
+	Statement int `json:"statement"`
+	// The amount of local variables that the block has declared so far
+	Declared int `json:"declared"`
+
+	// Whether the run is inside the body of the statement, which is then the block of the next frame
+	Entered bool `json:"entered,omitempty"`
+
+	// The branch of an if statement that is being run
+	Branch int `json:"branch,omitempty"`
+	// The array keys that a for every statement has yet to loop over
+	Keys []int `json:"keys,omitempty"`
+	// The characters that a for every statement has yet to loop over
+	Characters string `json:"characters,omitempty"`
+	// The current and last value of a for every range statement
+	Current  float64 `json:"current,omitempty"`
+	End      float64 `json:"end,omitempty"`
+	Forwards bool    `json:"forwards,omitempty"`
+}
+
+// Forget the state of the previous statement.
+func (f *Frame) next() {
+	*f = Frame{Statement: f.Statement + 1, Declared: f.Declared}
+}
+
+// An ExecutionState is where a run is at, so that it can be continued later.
+type ExecutionState struct {
+	// Every block of statements that is being run, starting from the paragraph's body
+	Frames []Frame `json:"frames"`
+	// Whether the last prompt has reached the end of its input
+	EndOfInput bool `json:"end_of_input,omitempty"`
+}
+
+// Returns where the run is at, to continue it later with Paragraph.Resume.
+//
+// The statement of the last frame is run again when resuming, and every
+// other frame must be inside the body of its statement.
+func (i *Interpreter) State() (*ExecutionState, error) {
+	state := &ExecutionState{
+		Frames:     make([]Frame, 0, len(i.frames)),
+		EndOfInput: i.endOfInput,
+	}
+
+	for idx, f := range i.frames {
+		if idx < len(i.frames)-1 && !f.Entered {
+			return nil, ErrNotResumable
+		}
+
+		frame := *f
+		frame.Keys = slices.Clone(f.Keys)
+		state.Frames = append(state.Frames, frame)
+	}
+
+	return state, nil
+}
+
+func (s *ExecutionState) validate() error {
+	if len(s.Frames) == 0 {
+		return fmt.Errorf("%w: there are no frames", ErrInvalidFrames)
+	}
+
+	for idx, frame := range s.Frames {
+		if frame.Entered != (idx < len(s.Frames)-1) {
+			return fmt.Errorf("%w: frame %d is not inside its statement", ErrInvalidFrames, idx)
+		}
+	}
+
+	return nil
+}
+
+// Returns the frame to continue the next block of statements from, or nil if it is run from the start.
+func (i *Interpreter) nextResumeFrame() *Frame {
+	if len(i.resuming) == 0 {
+		return nil
+	}
+
+	frame := i.resuming[0]
+	i.resuming = i.resuming[1:]
+	return &frame
+}
+
+// Returns the scope of the next paragraph call to continue while resuming a run.
+func (i *Interpreter) nextResumeScope() (*stack.Stack[*Variable], error) {
+	if len(i.resumingScopes) == 0 {
+		return nil, fmt.Errorf("%w: there is no scope for the paragraph", ErrInvalidFrames)
+	}
+
+	scope := i.resumingScopes[0]
+	i.resumingScopes = i.resumingScopes[1:]
+	return scope, nil
+}
+
+// Continue the statement that a resumed run was suspended inside of.
+func (i *Interpreter) resumeStatement(f *Frame, statement node.DynamicNode, resume *Frame) (*variable.DynamicVariable, error) {
+	switch n := statement.(type) {
+	case *nodes.IfStatementNode:
+		return i.evaluateIfStatementNode(f, n, resume)
+	case *nodes.WhileStatementNode:
+		return i.evaluateWhileStatementNode(f, n, resume)
+	case *nodes.ForEveryArrayStatementNode:
+		return i.evaluateForEveryArrayStatementNode(f, n, resume)
+	case *nodes.ForEveryRangeStatementNode:
+		return i.evaluateForEveryRangeStatementNode(f, n, resume)
+	case *nodes.FunctionCallNode:
+		return nil, i.evaluateFunctionCallNode(f, n, resume)
+	default:
+		return nil, fmt.Errorf("%w: statement %d has no body", ErrInvalidFrames, f.Statement)
+	}
+}
@@ -2,25 +2,71 @@ package celestia
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
+	"git.jaezmien.com/Jaezmien/fim/luna/stack"
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
 	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
 	"git.jaezmien.com/Jaezmien/fim/spike/variable"
 )
 
+// Returned by a Prompt when there is no scripted input left to answer it with.
+// The error is reported at the position of the prompt statement.
+var ErrOutOfInput = errors.New("Ran out of scripted input")
+
+// An Interpreter holds the state of a single run of a report.
+//
+// The ReportNode is only ever read, so multiple interpreters
+// can share the same ReportNode concurrently.
 type Interpreter struct {
 	Writer      io.Writer
 	ErrorWriter io.Writer
 
+	// Answers the prompt statements. Returning io.EOF reports the end of the input,
+	// and sets the variable to its default value
 	Prompt func(prompt string) (string, error)
+	// Reports whether the next prompt has input to read, for 'there is more to read'.
+	// If nil, there is more to read until a prompt reaches the end of its input
+	MoreInput func() bool
+
+	// Skip every assertion statement
+	DisableAssertions bool
+
+	// Directory that the file statements are confined to. If empty, file access is disabled
+	Sandbox string
+	// Environment variables that the report is allowed to read, and their values
+	Environment map[string]string
+
+	// Execution stops with the context's error once it is done
+	Context context.Context
+	// Called before every statement is executed. Returning an error stops execution
+	StatementHook func(statement node.DynamicNode) error
+
+	// Whether the last prompt has reached the end of its input
+	endOfInput bool
+	// Every block of statements that is being run
+	frames []*Frame
+	// The frames that are left to continue while resuming a run
+	resuming []Frame
+	// The scopes of the paragraphs that are left to continue while resuming a run
+	resumingScopes []*stack.Stack[*Variable]
+	// The predefined Arguments array, or nil if the report declares its own
+	arguments *variable.DynamicVariable
 
 	reportNode *nodes.ReportNode
-	source     string
+	source     *luna.SourceFile
 
 	Variables  *VariableManager
 	Paragraphs []*Paragraph
+
+	// The state of the variables right after the globals were declared
+	initial *VariableSnapshot
 }
 
 // Create a new interpreter based on the ReportNode
@@ -28,27 +74,31 @@ func NewInterpreter(reportNode *nodes.ReportNode, source string) (*Interpreter,
 	interpreter := &Interpreter{
 		Writer:      os.Stdout,
 		ErrorWriter: os.Stderr,
+		Context:     context.Background(),
 		reportNode:  reportNode,
-		source:      source,
+		source:      luna.NewSourceFile(source),
 		Paragraphs:  make([]*Paragraph, 0),
 		Variables:   NewVariableManager(),
 	}
 
+	// The same reader is kept between prompts, so that piped input is not lost
+	reader := bufio.NewReader(os.Stdin)
 	interpreter.Prompt = func(prompt string) (string, error) {
 		interpreter.Writer.Write([]byte(prompt))
 
-		scanner := bufio.NewScanner(os.Stdin)
-
-		var response string
-		for scanner.Scan() {
-			response = scanner.Text()
-			break
+		response, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) && response == "" {
+			return "", io.EOF
 		}
-		if err := scanner.Err(); err != nil {
+		if err != nil && !errors.Is(err, io.EOF) {
 			return "", err
 		}
 
-		return response, nil
+		return strings.TrimRight(response, "\r\n"), nil
+	}
+	interpreter.MoreInput = func() bool {
+		_, err := reader.Peek(1)
+		return err == nil
 	}
 
 	for _, n := range interpreter.reportNode.Body {
@@ -88,10 +138,6 @@ func NewInterpreter(reportNode *nodes.ReportNode, source string) (*Interpreter,
 				}
 			}
 
-			if !variableNode.ValueType.IsArray() {
-				value = value.Clone()
-			}
-
 			variable := &Variable{
 				Name:            variableNode.Identifier,
 				DynamicVariable: value,
@@ -106,9 +152,35 @@ func NewInterpreter(reportNode *nodes.ReportNode, source string) (*Interpreter,
 		return nil, n.ToNode().CreateError("Unsupported report body node", interpreter.source)
 	}
 
+	interpreter.declarePredefinedVariables()
+
+	interpreter.initial = interpreter.Variables.Snapshot()
+
 	return interpreter, nil
 }
 
+// Restore every global variable to its initial value, remove every local scope,
+// and forget that the prompts have reached the end of their input.
+//
+// This allows the report to be run again without having to parse it again.
+func (i *Interpreter) Reset() {
+	i.Variables.Restore(i.initial)
+	i.endOfInput = false
+}
+
+// Checks if execution can continue before running the statement.
+func (i *Interpreter) checkpoint(statement node.DynamicNode) error {
+	if err := i.Context.Err(); err != nil {
+		return err
+	}
+
+	if i.StatementHook != nil {
+		return i.StatementHook(statement)
+	}
+
+	return nil
+}
+
 // Return the report's title
 func (i *Interpreter) ReportTitle() string {
 	return i.reportNode.Title
@@ -2,10 +2,14 @@ package celestia
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"git.jaezmien.com/Jaezmien/fim/spike"
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
 	"git.jaezmien.com/Jaezmien/fim/twilight"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,6 +18,12 @@ type BasicReportOptions struct {
 	Expects string
 	Error   bool
 	Prompt  func(prompt string) (string, error)
+	Sandbox string
+
+	MoreInput func() bool
+
+	Environment map[string]string
+	Arguments   []string
 }
 
 func CreateReport(t *testing.T, source string, options BasicReportOptions) (*Interpreter, bool) {
@@ -63,8 +73,12 @@ func ExecuteBasicReport(t *testing.T, source string, options BasicReportOptions)
 
 	buffer := &bytes.Buffer{}
 	interpreter.Writer = buffer
+	interpreter.Sandbox = options.Sandbox
+	interpreter.Environment = options.Environment
+	interpreter.SetArguments(options.Arguments)
 	if options.Prompt != nil {
 		interpreter.Prompt = options.Prompt
+		interpreter.MoreInput = options.MoreInput
 	}
 
 	mainParagraph, ok := GetMainParagraph(t, interpreter)
@@ -283,6 +297,60 @@ func TestIO(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("should read until the end of input", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Prompts!
+			Today I learned how to echo every line!
+			Did you know that Line is a word?
+			As long as there is more to read,
+				I asked Line: "".
+				I said "> " plus Line!
+			That's what I did.
+			That's all about how to echo every line.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		lines := []string{"Apple", "", "Jack"}
+		ExecuteBasicReport(t, source, BasicReportOptions{
+			Expects: "> Apple\n> \n> Jack\n",
+			Prompt: func(prompt string) (string, error) {
+				if len(lines) == 0 {
+					return "", io.EOF
+				}
+				line := lines[0]
+				lines = lines[1:]
+				return line, nil
+			},
+			MoreInput: func() bool {
+				return len(lines) > 0
+			},
+		})
+	})
+
+	t.Run("should set the default value at the end of input", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Prompts!
+			Today I learned how to acquire prompts!
+			Did you know that Spike is the number 5?
+			I asked Spike: "Give me a number! ".
+			I said Spike!
+			If there is more to read then,
+				I said "More"!
+			Otherwise,
+				I said "Done"!
+			That's what I would do.
+			That's all about how to acquire prompts.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{
+			Expects: "0\nDone\n",
+			Prompt: func(prompt string) (string, error) {
+				return "", io.EOF
+			},
+		})
+	})
 }
 
 func TestBasicReports(t *testing.T) {
@@ -716,6 +784,43 @@ func TestArray(t *testing.T) {
 
 		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "Gala\nGala!\nGala\n"})
 	})
+	t.Run("should not share arrays between interpreters", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Arrays!
+			Did you know that Apples has many words?
+			Today I learned how to modify arrays!
+			I said 1 of Apples!
+			1 of Apples is "Gala".
+			That's all about how to modify arrays.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		report, err := spike.CreateReport(twilight.Parse(source), source)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for range 2 {
+			interpreter, err := NewInterpreter(report, source)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			buffer := &bytes.Buffer{}
+			interpreter.Writer = buffer
+
+			mainParagraph, ok := GetMainParagraph(t, interpreter)
+			if !ok {
+				return
+			}
+
+			_, err = mainParagraph.Execute()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "\n", buffer.String())
+		}
+	})
 }
 
 func TestFunctions(t *testing.T) {
@@ -1067,3 +1172,287 @@ func TestForEveryStatements(t *testing.T) {
 		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "Gala\nRed Delicious\nMcintosh\nHoneycrisp\n"})
 	})
 }
+
+func TestSuggestions(t *testing.T) {
+	t.Run("should suggest a similar variable", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Suggestions!
+			Today I learned how to misspell!
+			Did you know that Applejack is the number 1?
+			I said Applejak!
+			That's all about how to misspell.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+		interpreter.Writer = &bytes.Buffer{}
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Contains(t, err.Error(), "Unknown identifier (Applejak). Did you mean 'Applejack'?")
+	})
+	t.Run("should suggest a similar paragraph", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Suggestions!
+			I learned how to say hello!
+			I said "Hello"!
+			That's all about how to say hello.
+			Today I learned how to misspell!
+			I remembered how to say helo.
+			That's all about how to misspell.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+		interpreter.Writer = &bytes.Buffer{}
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Contains(t, err.Error(), "Paragraph 'how to say helo' not found. Did you mean 'how to say hello'?")
+	})
+	t.Run("should suggest a similar keyword", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Suggestions!
+			Today I learned how to misspell!
+			Did you knew that Spike is the number 1?
+			That's all about how to misspell.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		tokens := twilight.Parse(source)
+		_, err := spike.CreateReport(tokens, source)
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Contains(t, err.Error(), "Did you mean 'Did you know that'?")
+	})
+	t.Run("should leave the message as is without a suggestion", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Suggestions!
+			Today I learned how to misspell!
+			Did you know that Applejack is the number 1?
+			I said Rarity!
+			That's all about how to misspell.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+		interpreter.Writer = &bytes.Buffer{}
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Contains(t, err.Error(), "Unknown identifier (Rarity)\n")
+		assert.NotContains(t, err.Error(), "Did you mean")
+	})
+	t.Run("should keep the period of a missing variable without a suggestion", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Suggestions!
+			Today I learned how to misspell!
+			Did you know that Applejack is the number 1?
+			Rarity is now 2.
+			That's all about how to misspell.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+		interpreter.Writer = &bytes.Buffer{}
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Contains(t, err.Error(), "Variable 'Rarity' does not exist.\n")
+		assert.NotContains(t, err.Error(), "Did you mean")
+	})
+}
+
+func TestAssertions(t *testing.T) {
+	t.Run("should pass a true assertion", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Assertions!
+			Today I learned how to assert!
+			Did you know that Applejack is the number 1?
+			I made sure that Applejack is 1.
+			I said "Passed"!
+			That's all about how to assert.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Expects: "Passed\n"})
+	})
+	t.Run("should fail a false assertion", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Assertions!
+			Today I learned how to assert!
+			Did you know that Applejack is the number 1?
+			I made sure that Applejack plus 1 is greater than 5.
+			I said "Passed"!
+			That's all about how to assert.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+		buffer := &bytes.Buffer{}
+		interpreter.Writer = buffer
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Contains(t, err.Error(), "Assertion failed: Applejack plus 1 is greater than 5")
+		assert.Empty(t, buffer.String())
+	})
+	t.Run("should error on a non-boolean assertion", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Assertions!
+			Today I learned how to assert!
+			I made sure that "Applejack".
+			That's all about how to assert.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		ExecuteBasicReport(t, source, BasicReportOptions{Error: true})
+	})
+	t.Run("should skip disabled assertions", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Assertions!
+			Today I learned how to assert!
+			I made sure that 1 is 2.
+			I said "Skipped"!
+			That's all about how to assert.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+		buffer := &bytes.Buffer{}
+		interpreter.Writer = buffer
+		interpreter.DisableAssertions = true
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Skipped\n", buffer.String())
+	})
+}
+
+func TestCancellation(t *testing.T) {
+	t.Run("should stop a running report", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Cancellation!
+			Today I learned how to loop forever!
+			As long as true,
+			That's what I did.
+			That's all about how to loop forever.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		interpreter.Context = ctx
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("should stop when the statement hook errors", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Cancellation!
+			Today I learned how to count!
+			I said 1!
+			I said 2!
+			I said 3!
+			That's all about how to count.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+		buffer := &bytes.Buffer{}
+		interpreter.Writer = buffer
+
+		stop := errors.New("stop")
+		count := 0
+		interpreter.StatementHook = func(statement node.DynamicNode) error {
+			count++
+			if count > 2 {
+				return stop
+			}
+			return nil
+		}
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, "1\n2\n", buffer.String())
+	})
+}
@@ -2,7 +2,9 @@ package celestia
 
 import (
 	"fmt"
+	"slices"
 
+	"git.jaezmien.com/Jaezmien/fim/luna/stack"
 	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
 	"git.jaezmien.com/Jaezmien/fim/spike/variable"
 )
@@ -70,6 +72,42 @@ func (p *Paragraph) Execute(parameters ...*variable.DynamicVariable) (*variable.
 		}
 	}
 
+	return p.evaluateBody()
+}
+
+// Continue a suspended run of the paragraph from its state.
+//
+// The variables must already be restored from the same point in time,
+// which includes the scope of every paragraph that was being run.
+func (p *Paragraph) Resume(state *ExecutionState) (*variable.DynamicVariable, error) {
+	if err := state.validate(); err != nil {
+		return nil, err
+	}
+
+	variables := p.Interpreter.Variables
+	if variables.ScopeDepth() == 0 {
+		return nil, fmt.Errorf("%w: there is no scope to resume in", ErrInvalidFrames)
+	}
+
+	// The scopes of the called paragraphs are set aside until their call is continued
+	scopes := make([]*stack.Stack[*Variable], variables.ScopeDepth()-1)
+	for idx := len(scopes) - 1; idx >= 0; idx-- {
+		scopes[idx] = *variables.Locals.Pop()
+	}
+
+	p.Interpreter.endOfInput = state.EndOfInput
+	p.Interpreter.resuming = slices.Clone(state.Frames)
+	p.Interpreter.resumingScopes = scopes
+	defer func() {
+		p.Interpreter.resuming = nil
+		p.Interpreter.resumingScopes = nil
+	}()
+
+	return p.evaluateBody()
+}
+
+// Run the body of the paragraph, whose scope is already pushed.
+func (p *Paragraph) evaluateBody() (*variable.DynamicVariable, error) {
 	value, err := p.Interpreter.EvaluateStatementsNode(p.FunctionNode.Body)
 	p.Interpreter.Variables.PopScope()
 
@@ -0,0 +1,106 @@
+package celestia
+
+import (
+	"reflect"
+	"unsafe"
+
+	"git.jaezmien.com/Jaezmien/fim/luna/stack"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+// A VariableSnapshot is a copy of a VariableManager's state at one point in time.
+//
+// A snapshot is never modified after it is taken, so it can be restored any
+// amount of times.
+type VariableSnapshot struct {
+	globals []*Variable
+	locals  [][]*Variable
+}
+
+// Take a snapshot of every global and local variable.
+func (m *VariableManager) Snapshot() *VariableSnapshot {
+	c := newVariableCopier()
+
+	snapshot := &VariableSnapshot{
+		globals: c.copyStack(&m.Globals),
+		locals:  make([][]*Variable, 0, m.Locals.Len()),
+	}
+	for idx := 0; idx < m.Locals.Len(); idx += 1 {
+		snapshot.locals = append(snapshot.locals, c.copyStack(m.Locals.PeekAt(idx)))
+	}
+
+	return snapshot
+}
+
+// Replace every global and local variable with the ones in the snapshot.
+func (m *VariableManager) Restore(snapshot *VariableSnapshot) {
+	c := newVariableCopier()
+
+	m.Globals = *stack.New[*Variable]()
+	for _, v := range snapshot.globals {
+		m.Globals.Push(c.copyVariable(v))
+	}
+
+	m.Locals = *stack.New[*stack.Stack[*Variable]]()
+	for _, scope := range snapshot.locals {
+		current := stack.New[*Variable]()
+		for _, v := range scope {
+			current.Push(c.copyVariable(v))
+		}
+		m.Locals.Push(current)
+	}
+}
+
+// Returns what identifies the array of the variable.
+//
+// Cloning an array variable keeps its dictionary, so variables can hold
+// different wrappers around the same array.
+func arrayIdentity(v *variable.DynamicVariable) unsafe.Pointer {
+	return reflect.ValueOf(v.GetValueDictionary()).UnsafePointer()
+}
+
+// Arrays are passed around by reference, so a copier remembers which
+// arrays it has already copied to keep variables pointing to the same array.
+type variableCopier struct {
+	arrays map[unsafe.Pointer]*variable.DynamicVariable
+}
+
+func newVariableCopier() *variableCopier {
+	return &variableCopier{
+		arrays: make(map[unsafe.Pointer]*variable.DynamicVariable),
+	}
+}
+
+func (c *variableCopier) copyStack(s *stack.Stack[*Variable]) []*Variable {
+	variables := make([]*Variable, 0, s.Len())
+	for idx := 0; idx < s.Len(); idx += 1 {
+		variables = append(variables, c.copyVariable(s.PeekAt(idx)))
+	}
+	return variables
+}
+
+func (c *variableCopier) copyVariable(v *Variable) *Variable {
+	return &Variable{
+		Name:            v.Name,
+		DynamicVariable: c.copyValue(v.DynamicVariable),
+		Constant:        v.Constant,
+	}
+}
+
+func (c *variableCopier) copyValue(v *variable.DynamicVariable) *variable.DynamicVariable {
+	if !v.GetType().IsArray() {
+		return v.Clone()
+	}
+
+	if array, ok := c.arrays[arrayIdentity(v)]; ok {
+		return array
+	}
+
+	array := variable.NewDictionaryVariable(v.GetType())
+	for idx, value := range v.GetValueDictionary() {
+		array.GetValueDictionary()[idx] = value.Clone()
+	}
+	c.arrays[arrayIdentity(v)] = array
+
+	return array
+}
@@ -0,0 +1,229 @@
+package celestia
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unsafe"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+// The JSON representation of a VariableSnapshot.
+//
+// Arrays are stored once in Arrays, and referenced by index by every
+// variable that points to them.
+type snapshotJSON struct {
+	Globals []variableJSON   `json:"globals"`
+	Locals  [][]variableJSON `json:"locals"`
+	Arrays  []arrayJSON      `json:"arrays"`
+}
+
+type variableJSON struct {
+	Name     string          `json:"name"`
+	Type     string          `json:"type"`
+	Constant bool            `json:"constant,omitempty"`
+	Value    json.RawMessage `json:"value,omitempty"`
+	Array    *int            `json:"array,omitempty"`
+}
+
+type arrayJSON struct {
+	Type   string                     `json:"type"`
+	Values map[string]json.RawMessage `json:"values"`
+}
+
+func (s *VariableSnapshot) MarshalJSON() ([]byte, error) {
+	data := snapshotJSON{
+		Globals: make([]variableJSON, 0, len(s.globals)),
+		Locals:  make([][]variableJSON, 0, len(s.locals)),
+		Arrays:  make([]arrayJSON, 0),
+	}
+	arrays := make(map[unsafe.Pointer]int)
+
+	encodeVariables := func(variables []*Variable) ([]variableJSON, error) {
+		encoded := make([]variableJSON, 0, len(variables))
+		for _, v := range variables {
+			entry := variableJSON{
+				Name:     v.Name,
+				Type:     v.GetType().String(),
+				Constant: v.Constant,
+			}
+
+			if v.GetType().IsArray() {
+				index, ok := arrays[arrayIdentity(v.DynamicVariable)]
+				if !ok {
+					array := arrayJSON{
+						Type:   v.GetType().String(),
+						Values: make(map[string]json.RawMessage),
+					}
+					for idx, value := range v.GetValueDictionary() {
+						raw, err := encodeValue(value)
+						if err != nil {
+							return nil, err
+						}
+						array.Values[strconv.Itoa(idx)] = raw
+					}
+
+					index = len(data.Arrays)
+					arrays[arrayIdentity(v.DynamicVariable)] = index
+					data.Arrays = append(data.Arrays, array)
+				}
+				entry.Array = &index
+			} else {
+				raw, err := encodeValue(v.DynamicVariable)
+				if err != nil {
+					return nil, err
+				}
+				entry.Value = raw
+			}
+
+			encoded = append(encoded, entry)
+		}
+		return encoded, nil
+	}
+
+	globals, err := encodeVariables(s.globals)
+	if err != nil {
+		return nil, err
+	}
+	data.Globals = globals
+
+	for _, scope := range s.locals {
+		locals, err := encodeVariables(scope)
+		if err != nil {
+			return nil, err
+		}
+		data.Locals = append(data.Locals, locals)
+	}
+
+	return json.Marshal(data)
+}
+
+func (s *VariableSnapshot) UnmarshalJSON(raw []byte) error {
+	var data snapshotJSON
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return err
+	}
+
+	arrays := make([]*variable.DynamicVariable, 0, len(data.Arrays))
+	for _, array := range data.Arrays {
+		t, ok := variable.ParseVariableType(array.Type)
+		if !ok || !t.IsArray() {
+			return fmt.Errorf("Invalid array type '%s'", array.Type)
+		}
+
+		dictionary := variable.NewDictionaryVariable(t)
+		for key, rawValue := range array.Values {
+			idx, err := strconv.Atoi(key)
+			if err != nil {
+				return fmt.Errorf("Invalid array index '%s'", key)
+			}
+
+			value, err := decodeValue(rawValue, t.AsBaseType())
+			if err != nil {
+				return err
+			}
+			dictionary.GetValueDictionary()[idx] = value
+		}
+
+		arrays = append(arrays, dictionary)
+	}
+
+	decodeVariables := func(encoded []variableJSON) ([]*Variable, error) {
+		variables := make([]*Variable, 0, len(encoded))
+		for _, entry := range encoded {
+			t, ok := variable.ParseVariableType(entry.Type)
+			if !ok {
+				return nil, fmt.Errorf("Invalid type '%s' of variable '%s'", entry.Type, entry.Name)
+			}
+
+			v := &Variable{
+				Name:     entry.Name,
+				Constant: entry.Constant,
+			}
+
+			if t.IsArray() {
+				if entry.Array == nil || *entry.Array < 0 || *entry.Array >= len(arrays) {
+					return nil, fmt.Errorf("Invalid array of variable '%s'", entry.Name)
+				}
+				v.DynamicVariable = arrays[*entry.Array]
+				if v.GetType() != t {
+					return nil, fmt.Errorf("Expected array of type '%s' for variable '%s', got '%s'", t, entry.Name, v.GetType())
+				}
+			} else {
+				value, err := decodeValue(entry.Value, t)
+				if err != nil {
+					return nil, fmt.Errorf("Invalid value of variable '%s': %w", entry.Name, err)
+				}
+				v.DynamicVariable = value
+			}
+
+			variables = append(variables, v)
+		}
+		return variables, nil
+	}
+
+	globals, err := decodeVariables(data.Globals)
+	if err != nil {
+		return err
+	}
+
+	locals := make([][]*Variable, 0, len(data.Locals))
+	for _, scope := range data.Locals {
+		variables, err := decodeVariables(scope)
+		if err != nil {
+			return err
+		}
+		locals = append(locals, variables)
+	}
+
+	s.globals = globals
+	s.locals = locals
+	return nil
+}
+
+func encodeValue(v *variable.DynamicVariable) (json.RawMessage, error) {
+	switch v.GetType() {
+	case variable.BOOLEAN:
+		return json.Marshal(v.GetValueBoolean())
+	case variable.NUMBER:
+		return json.Marshal(v.GetValueNumber())
+	case variable.CHARACTER:
+		return json.Marshal(v.GetValueCharacter())
+	case variable.STRING:
+		return json.Marshal(v.GetValueString())
+	default:
+		return nil, fmt.Errorf("Cannot serialize a value of type '%s'", v.GetType())
+	}
+}
+
+func decodeValue(raw json.RawMessage, t variable.VariableType) (*variable.DynamicVariable, error) {
+	switch t {
+	case variable.BOOLEAN:
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		return variable.NewBooleanVariable(value), nil
+	case variable.NUMBER:
+		var value float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		return variable.NewNumberVariable(value), nil
+	case variable.CHARACTER:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		return variable.NewRawCharacterVariable(value), nil
+	case variable.STRING:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		return variable.NewRawStringVariable(value), nil
+	default:
+		return nil, fmt.Errorf("Cannot deserialize a value of type '%s'", t)
+	}
+}
@@ -0,0 +1,281 @@
+package celestia
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReset(t *testing.T) {
+	source :=
+		`Dear Princess Celestia: Resetting!
+		Did you know that Counter is the number 0?
+		Did you know that Apples has many words?
+		Today I learned how to count!
+		Counter got one more.
+		1 of Apples is "Gala".
+		I said Counter!
+		I said 1 of Apples!
+		That's all about how to count.
+		Your faithful student, Twilight Sparkle.
+		`
+
+	interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+	if !ok {
+		return
+	}
+
+	buffer := &bytes.Buffer{}
+	interpreter.Writer = buffer
+
+	mainParagraph, ok := GetMainParagraph(t, interpreter)
+	if !ok {
+		return
+	}
+
+	t.Run("should keep state without a reset", func(t *testing.T) {
+		buffer.Reset()
+		for range 2 {
+			_, err := mainParagraph.Execute()
+			if !assert.NoError(t, err) {
+				return
+			}
+		}
+		assert.Equal(t, "1\nGala\n2\nGala\n", buffer.String())
+	})
+
+	t.Run("should restore globals after a reset", func(t *testing.T) {
+		interpreter.Reset()
+		assert.Equal(t, float64(0), interpreter.Variables.Get("Counter", false).GetValueNumber())
+		assert.Empty(t, interpreter.Variables.Get("Apples", false).GetValueDictionary())
+
+		buffer.Reset()
+		for range 2 {
+			_, err := mainParagraph.Execute()
+			if !assert.NoError(t, err) {
+				return
+			}
+			interpreter.Reset()
+		}
+		assert.Equal(t, "1\nGala\n1\nGala\n", buffer.String())
+	})
+
+	t.Run("should read more input after a reset", func(t *testing.T) {
+		source :=
+			`Dear Princess Celestia: Resetting!
+			Today I learned how to read!
+			If there is more to read then,
+				I said "More"!
+			Otherwise,
+				I said "Done"!
+			That's what I would do.
+			Did you know that Line is a word?
+			I asked Line: "".
+			That's all about how to read.
+			Your faithful student, Twilight Sparkle.
+			`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+
+		buffer := &bytes.Buffer{}
+		interpreter.Writer = buffer
+		interpreter.Prompt = func(prompt string) (string, error) {
+			return "", io.EOF
+		}
+		interpreter.MoreInput = nil
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		for range 2 {
+			_, err := mainParagraph.Execute()
+			if !assert.NoError(t, err) {
+				return
+			}
+		}
+		assert.Equal(t, "More\nDone\n", buffer.String())
+
+		interpreter.Reset()
+
+		buffer.Reset()
+		_, err := mainParagraph.Execute()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "More\n", buffer.String())
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	source :=
+		`Dear Princess Celestia: Snapshots!
+		Did you know that Counter is the number 0?
+		Did you know that Apples has the words "Gala", "Mcintosh"?
+		Today I learned how to count!
+		That's all about how to count.
+		Your faithful student, Twilight Sparkle.
+		`
+
+	t.Run("should restore a snapshot", func(t *testing.T) {
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+
+		snapshot := interpreter.Variables.Snapshot()
+
+		interpreter.Variables.Get("Counter", false).SetValueNumber(5)
+		interpreter.Variables.Get("Apples", false).GetValueDictionary()[1].SetValueString("Honeycrisp")
+
+		interpreter.Variables.Restore(snapshot)
+		assert.Equal(t, float64(0), interpreter.Variables.Get("Counter", false).GetValueNumber())
+		assert.Equal(t, "Gala", interpreter.Variables.Get("Apples", false).GetValueDictionary()[1].GetValueString())
+	})
+
+	t.Run("should restore a snapshot more than once", func(t *testing.T) {
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+
+		snapshot := interpreter.Variables.Snapshot()
+
+		for range 2 {
+			interpreter.Variables.Restore(snapshot)
+			interpreter.Variables.Get("Counter", false).SetValueNumber(5)
+		}
+
+		interpreter.Variables.Restore(snapshot)
+		assert.Equal(t, float64(0), interpreter.Variables.Get("Counter", false).GetValueNumber())
+	})
+
+	t.Run("should keep arrays shared between variables", func(t *testing.T) {
+		apples := variable.NewDictionaryVariable(variable.STRING_ARRAY)
+
+		manager := NewVariableManager()
+		manager.PushVariable(&Variable{Name: "Apples", DynamicVariable: apples}, true)
+		manager.PushScope()
+		manager.PushVariable(&Variable{Name: "Fruits", DynamicVariable: apples}, false)
+
+		manager.Restore(manager.Snapshot())
+		assert.Same(t, manager.Get("Apples", true).DynamicVariable, manager.Get("Fruits", true).DynamicVariable)
+		assert.NotSame(t, apples, manager.Get("Apples", true).DynamicVariable)
+	})
+
+	t.Run("should keep arrays passed to a paragraph shared", func(t *testing.T) {
+		source := `Dear Princess Celestia: Snapshots!
+		Did you know that Apples has the words "Gala", "Mcintosh"?
+		I learned how to pick using the words Fruits!
+			1 of Fruits is "Honeycrisp".
+		That's all about how to pick.
+		Today I learned how to count!
+			I remembered how to pick using Apples.
+			I said 1 of Apples!
+		That's all about how to count.
+		Your faithful student, Twilight Sparkle.`
+
+		interpreter, ok := CreateReport(t, source, BasicReportOptions{})
+		if !ok {
+			return
+		}
+
+		buffer := &bytes.Buffer{}
+		interpreter.Writer = buffer
+
+		restored := false
+		interpreter.StatementHook = func(statement node.DynamicNode) error {
+			if !restored && interpreter.Variables.ScopeDepth() > 1 {
+				interpreter.Variables.Restore(interpreter.Variables.Snapshot())
+				restored = true
+			}
+			return nil
+		}
+
+		mainParagraph, ok := GetMainParagraph(t, interpreter)
+		if !ok {
+			return
+		}
+
+		_, err := mainParagraph.Execute()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, restored)
+		assert.Equal(t, "Honeycrisp\n", buffer.String())
+	})
+
+	t.Run("should restore local scopes", func(t *testing.T) {
+		manager := NewVariableManager()
+		manager.PushScope()
+		manager.PushVariable(&Variable{Name: "Spike", DynamicVariable: variable.NewNumberVariable(1)}, false)
+
+		snapshot := manager.Snapshot()
+		manager.PopScope()
+		manager.PushScope()
+		manager.PushScope()
+
+		manager.Restore(snapshot)
+		assert.Equal(t, 1, manager.ScopeDepth())
+		if assert.True(t, manager.Has("Spike", true)) {
+			assert.Equal(t, float64(1), manager.Get("Spike", true).GetValueNumber())
+		}
+	})
+	t.Run("should serialize a snapshot", func(t *testing.T) {
+		apples := variable.NewDictionaryVariable(variable.STRING_ARRAY)
+		apples.GetValueDictionary()[1] = variable.NewRawStringVariable("Gala")
+		apples.GetValueDictionary()[3] = variable.NewRawStringVariable("Mcintosh")
+
+		manager := NewVariableManager()
+		manager.PushVariable(&Variable{Name: "Apples", DynamicVariable: apples}, true)
+		manager.PushVariable(&Variable{Name: "Pi", DynamicVariable: variable.NewNumberVariable(3.14), Constant: true}, true)
+		manager.PushScope()
+		manager.PushVariable(&Variable{Name: "Fruits", DynamicVariable: apples}, false)
+		manager.PushVariable(&Variable{Name: "Ready", DynamicVariable: variable.NewBooleanVariable(true)}, false)
+		manager.PushVariable(&Variable{Name: "Letter", DynamicVariable: variable.NewRawCharacterVariable("a")}, false)
+
+		data, err := json.Marshal(manager.Snapshot())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		snapshot := &VariableSnapshot{}
+		if !assert.NoError(t, json.Unmarshal(data, snapshot)) {
+			return
+		}
+
+		restored := NewVariableManager()
+		restored.Restore(snapshot)
+
+		assert.Equal(t, 1, restored.ScopeDepth())
+		assert.Equal(t, []string{"Apples", "Pi", "Fruits", "Ready", "Letter"}, restored.Names(true))
+		assert.Same(t, restored.Get("Apples", true).DynamicVariable, restored.Get("Fruits", true).DynamicVariable)
+		assert.Equal(t, "Mcintosh", restored.Get("Apples", true).GetValueDictionary()[3].GetValueString())
+		assert.Equal(t, 3.14, restored.Get("Pi", true).GetValueNumber())
+		assert.True(t, restored.Get("Pi", true).Constant)
+		assert.True(t, restored.Get("Ready", true).GetValueBoolean())
+		assert.Equal(t, "a", restored.Get("Letter", true).GetValueCharacter())
+
+		again, err := json.Marshal(restored.Snapshot())
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.JSONEq(t, string(data), string(again))
+	})
+
+	t.Run("should not deserialize an invalid snapshot", func(t *testing.T) {
+		snapshot := &VariableSnapshot{}
+		assert.Error(t, json.Unmarshal([]byte(`{"globals":[{"name":"Spike","type":"DRAGON"}]}`), snapshot))
+		assert.Error(t, json.Unmarshal([]byte(`{"globals":[{"name":"Spike","type":"NUMBER","value":"one"}]}`), snapshot))
+		assert.Error(t, json.Unmarshal([]byte(`{"globals":[{"name":"Spike","type":"ARRAY(NUMBER)","array":0}]}`), snapshot))
+	})
+}
@@ -1,9 +1,12 @@
 package celestia
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"slices"
 	"strconv"
+	"unicode/utf8"
 
 	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
 	"git.jaezmien.com/Jaezmien/fim/spike/variable"
@@ -12,12 +15,44 @@ import (
 )
 
 func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (*variable.DynamicVariable, error) {
-	newVariableCount := 0
+	f := &Frame{}
+	resume := i.nextResumeFrame()
+	if resume != nil {
+		if resume.Statement < 0 || resume.Statement >= len(statements.Statements) {
+			return nil, fmt.Errorf("%w: statement %d does not exist", ErrInvalidFrames, resume.Statement)
+		}
+		f.Statement = resume.Statement
+		f.Declared = resume.Declared
+	}
+
+	i.frames = append(i.frames, f)
 	defer func() {
-		i.Variables.PopVariableAmount(false, newVariableCount)
+		i.frames = i.frames[:len(i.frames)-1]
+		i.Variables.PopVariableAmount(false, f.Declared)
 	}()
 
-	for _, statement := range statements.Statements {
+	for ; f.Statement < len(statements.Statements); f.next() {
+		statement := statements.Statements[f.Statement]
+
+		// The statement that a resumed run was suspended inside of is continued, instead of being run again
+		if resume != nil && resume.Entered {
+			result, err := i.resumeStatement(f, statement, resume)
+			resume = nil
+
+			if result != nil || err != nil {
+				return result, err
+			}
+			continue
+		}
+		if resume != nil && len(i.resumingScopes) > 0 {
+			return nil, fmt.Errorf("%w: there are more scopes than paragraph calls", ErrInvalidFrames)
+		}
+		resume = nil
+
+		if err := i.checkpoint(statement); err != nil {
+			return nil, err
+		}
+
 		switch n := statement.(type) {
 		case *nodes.PrintNode:
 			value, err := i.EvaluateValueNode(n.Value, true)
@@ -36,7 +71,7 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 			}
 		case *nodes.PromptNode:
 			if !i.Variables.Has(n.Identifier, true) {
-				return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' does not exist.", n.Identifier), i.source)
+				return nil, n.ToNode().CreateError(i.variableNotFound(n.Identifier), i.source)
 			}
 			v := i.Variables.Get(n.Identifier, true)
 			if v.Constant {
@@ -56,6 +91,29 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 			}
 
 			response, err := i.Prompt(value.GetValueString())
+			if errors.Is(err, ErrOutOfInput) {
+				return nil, n.ToNode().CreateError(err.Error(), i.source)
+			}
+			i.endOfInput = errors.Is(err, io.EOF)
+			if i.endOfInput {
+				defaultValue, ok := v.GetType().GetDefaultValue()
+				if !ok {
+					panic("Intepreter@EvaluateStatementsNode could not get default value.")
+				}
+				value := variable.FromValueType(defaultValue, v.GetType())
+
+				switch v.GetType() {
+				case variable.STRING:
+					v.SetValueString(value.GetValueString())
+				case variable.CHARACTER:
+					v.SetValueCharacter(value.GetValueCharacter())
+				case variable.BOOLEAN:
+					v.SetValueBoolean(value.GetValueBoolean())
+				case variable.NUMBER:
+					v.SetValueNumber(value.GetValueNumber())
+				}
+				break
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -82,6 +140,22 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 				}
 				v.DynamicVariable.SetValueNumber(value)
 			}
+		case *nodes.FileReadNode:
+			if err := i.evaluateFileReadNode(n); err != nil {
+				return nil, err
+			}
+		case *nodes.FileReadLinesNode:
+			if err := i.evaluateFileReadLinesNode(n); err != nil {
+				return nil, err
+			}
+		case *nodes.FileWriteNode:
+			if err := i.evaluateFileWriteNode(n); err != nil {
+				return nil, err
+			}
+		case *nodes.EnvironmentReadNode:
+			if err := i.evaluateEnvironmentReadNode(n); err != nil {
+				return nil, err
+			}
 		case *nodes.VariableDeclarationNode:
 			if i.Variables.Get(n.Identifier, true) != nil {
 				return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' already exists.", n.Identifier), i.source)
@@ -119,11 +193,11 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 			}
 
 			i.Variables.PushVariable(variable, false)
-			newVariableCount += 1
+			f.Declared += 1
 
 		case *nodes.VariableModifyNode:
 			if !i.Variables.Has(n.Identifier, true) {
-				return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' does not exist.", n.Identifier), i.source)
+				return nil, n.ToNode().CreateError(i.variableNotFound(n.Identifier), i.source)
 			}
 			v := i.Variables.Get(n.Identifier, true)
 
@@ -169,7 +243,7 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 			}
 		case *nodes.ArrayModifyNode:
 			if !i.Variables.Has(n.Identifier, true) {
-				return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' does not exist.", n.Identifier), i.source)
+				return nil, n.ToNode().CreateError(i.variableNotFound(n.Identifier), i.source)
 			}
 			v := i.Variables.Get(n.Identifier, true)
 
@@ -213,227 +287,29 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 
 			v.GetValueDictionary()[int(index.GetValueNumber())] = value
 		case *nodes.IfStatementNode:
-			for _, branch := range n.Conditions {
-				check := true
-
-				if branch.Condition != nil {
-					branchCheck, err := i.EvaluateValueNode(*branch.Condition, true)
-					if err != nil {
-						return nil, err
-					}
-
-					if branchCheck.GetType() != variable.BOOLEAN {
-						return nil, branch.ToNode().CreateError(fmt.Sprintf("Expected condition to result in type %s, got %s", variable.BOOLEAN, branchCheck.GetType()), i.source)
-					}
-
-					check = branchCheck.GetValueBoolean()
-				}
-
-				if check {
-					result, err := i.EvaluateStatementsNode(&branch.StatementsNode)
-
-					if result != nil || err != nil {
-						return result, err
-					}
-
-					break
-				}
+			result, err := i.evaluateIfStatementNode(f, n, nil)
+			if result != nil || err != nil {
+				return result, err
 			}
 		case *nodes.WhileStatementNode:
-			for {
-				branchCheck, err := i.EvaluateValueNode(*n.Condition, true)
-				if err != nil {
-					return nil, err
-				}
-
-				if branchCheck.GetType() != variable.BOOLEAN {
-					return nil, n.ToNode().CreateError(fmt.Sprintf("Expected condition to result in type %s, got %s", variable.BOOLEAN, branchCheck.GetType()), i.source)
-				}
-
-				check := branchCheck.GetValueBoolean()
-
-				if !check {
-					break
-				}
-
-				result, err := i.EvaluateStatementsNode(&n.StatementsNode)
-
-				if result != nil || err != nil {
-					return result, err
-				}
+			result, err := i.evaluateWhileStatementNode(f, n, nil)
+			if result != nil || err != nil {
+				return result, err
 			}
 		case *nodes.ForEveryArrayStatementNode:
-			if !i.Variables.Has(n.Identifier, true) {
-				return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' does not exist.", n.Identifier), i.source)
-			}
-
-			v := i.Variables.Get(n.Identifier, true)
-
-			if v.GetType().IsArray() {
-				if v.GetType().AsBaseType() != n.VariableType {
-					return nil, n.ToNode().CreateError(fmt.Sprintf("Expected loop variable to be type %s, got %s", v.GetType().AsBaseType(), n.VariableType), i.source)
-				}
-			} else if v.GetType() == variable.STRING {
-				if variable.CHARACTER != n.VariableType {
-					return nil, n.ToNode().CreateError(fmt.Sprintf("Expected loop variable to be type %s, got %s", variable.CHARACTER, n.VariableType), i.source)
-				}
-			} else {
-				return nil, n.ToNode().CreateError(fmt.Sprintf("Expected an array variable, got type %s", v.GetType()), i.source)
-			}
-
-			if i.Variables.Has(n.VariableName, true) {
-				return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' already exists.", n.VariableName), i.source)
-			}
-
-			if v.GetType() == variable.STRING {
-				for _, c := range v.GetValueString() {
-					variable := &Variable{
-						Name:            n.VariableName,
-						DynamicVariable: variable.NewRawCharacterVariable(string(c)),
-						Constant:        true,
-					}
-
-					i.Variables.PushVariable(variable, false)
-					result, err := i.EvaluateStatementsNode(&n.StatementsNode)
-					if err != nil {
-						return nil, err
-					}
-
-					i.Variables.PopVariable(false)
-
-					if result != nil {
-						return result, err
-					}
-				}
-				break
+			result, err := i.evaluateForEveryArrayStatementNode(f, n, nil)
+			if result != nil || err != nil {
+				return result, err
 			}
-
-			keys := make([]int, 0, len(v.GetValueDictionary()))
-			for k := range v.GetValueDictionary() {
-				keys = append(keys, k)
-			}
-			slices.Sort(keys)
-
-			switch v.GetType() {
-			case variable.STRING_ARRAY:
-				for _, k := range keys {
-					v := v.GetValueDictionary()[k]
-
-					variable := &Variable{
-						Name:            n.VariableName,
-						DynamicVariable: variable.NewRawStringVariable(v.GetValueString()),
-						Constant:        true,
-					}
-
-					i.Variables.PushVariable(variable, false)
-					result, err := i.EvaluateStatementsNode(&n.StatementsNode)
-					i.Variables.PopVariable(false)
-
-					if result != nil || err != nil {
-						return result, err
-					}
-				}
-			case variable.BOOLEAN_ARRAY:
-				for _, k := range keys {
-					v := v.GetValueDictionary()[k]
-
-					variable := &Variable{
-						Name:            n.VariableName,
-						DynamicVariable: variable.NewBooleanVariable(v.GetValueBoolean()),
-						Constant:        true,
-					}
-
-					i.Variables.PushVariable(variable, false)
-					result, err := i.EvaluateStatementsNode(&n.StatementsNode)
-					i.Variables.PopVariable(false)
-
-					if result != nil || err != nil {
-						return result, err
-					}
-				}
-			case variable.NUMBER_ARRAY:
-				for _, k := range keys {
-					v := v.GetValueDictionary()[k]
-
-					variable := &Variable{
-						Name:            n.VariableName,
-						DynamicVariable: variable.NewNumberVariable(v.GetValueNumber()),
-						Constant:        true,
-					}
-
-					i.Variables.PushVariable(variable, false)
-					result, err := i.EvaluateStatementsNode(&n.StatementsNode)
-					i.Variables.PopVariable(false)
-
-					if result != nil || err != nil {
-						return result, err
-					}
-				}
-			}
-
 		case *nodes.ForEveryRangeStatementNode:
-			if i.Variables.Has(n.VariableName, true) {
-				return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' already exists.", n.VariableName), i.source)
-			}
-
-			fromRange, err := i.EvaluateValueNode(n.RangeStart, true)
-			if err != nil {
-				return nil, err
-			}
-			if fromRange.GetType() != variable.NUMBER {
-				return nil, n.RangeStart.ToNode().CreateError(fmt.Sprintf("Expected a number type, got %s", fromRange.GetType()), i.source)
-			}
-
-			toRange, err := i.EvaluateValueNode(n.RangeEnd, true)
-			if err != nil {
-				return nil, err
-			}
-			if toRange.GetType() != variable.NUMBER {
-				return nil, n.RangeEnd.ToNode().CreateError(fmt.Sprintf("Expected a number type, got %s", toRange.GetType()), i.source)
+			result, err := i.evaluateForEveryRangeStatementNode(f, n, nil)
+			if result != nil || err != nil {
+				return result, err
 			}
-
-			startValue := fromRange.GetValueNumber()
-			endValue := toRange.GetValueNumber()
-
-			currentValue := startValue
-
-			isForwards := true
-			if endValue < startValue {
-				isForwards = false
-			}
-
-			for {
-				if isForwards && currentValue > endValue {
-					break
-				} else if !isForwards && currentValue < endValue {
-					break
-				}
-
-				variable := &Variable{
-					Name:            n.VariableName,
-					DynamicVariable: variable.NewNumberVariable(currentValue),
-					Constant:        true,
-				}
-
-				i.Variables.PushVariable(variable, false)
-				result, err := i.EvaluateStatementsNode(&n.StatementsNode)
-				i.Variables.PopVariable(false)
-
-				if result != nil || err != nil {
-					return result, err
-				}
-
-				if isForwards {
-					currentValue += 1.0
-				} else {
-					currentValue -= 1.0
-				}
-			}
-
 		case *nodes.UnaryExpressionNode:
 			if in, ok := n.Identifier.(*nodes.IdentifierNode); ok {
 				if !i.Variables.Has(in.Identifier, true) {
-					return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' does not exist.", n.Identifier), i.source)
+					return nil, n.ToNode().CreateError(i.variableNotFound(in.Identifier), i.source)
 				}
 				v := i.Variables.Get(in.Identifier, true)
 
@@ -451,7 +327,7 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 				}
 			} else if in, ok := n.Identifier.(*nodes.DictionaryIdentifierNode); ok {
 				if !i.Variables.Has(in.Identifier, true) {
-					return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' does not exist.", n.Identifier), i.source)
+					return nil, n.ToNode().CreateError(i.variableNotFound(in.Identifier), i.source)
 				}
 				v := i.Variables.Get(in.Identifier, true)
 
@@ -482,33 +358,338 @@ func (i *Interpreter) EvaluateStatementsNode(statements *nodes.StatementsNode) (
 				v.GetValueDictionary()[int(idx.GetValueNumber())] = value
 			}
 		case *nodes.FunctionCallNode:
-			paragraphIndex := slices.IndexFunc(i.Paragraphs, func(p *Paragraph) bool { return p.Name == n.Identifier })
-			if paragraphIndex == -1 {
-				return nil, statement.ToNode().CreateError(fmt.Sprintf("Paragraph '%s' not found", n.Identifier), i.source)
+			if err := i.evaluateFunctionCallNode(f, n, nil); err != nil {
+				return nil, err
+			}
+		case *nodes.FunctionReturnNode:
+			value, err := i.EvaluateValueNode(n.Value, true)
+			return value, err
+		case *nodes.AssertNode:
+			if i.DisableAssertions {
+				continue
 			}
 
-			paragraph := i.Paragraphs[paragraphIndex]
+			check, err := i.EvaluateValueNode(n.Condition, true)
+			if err != nil {
+				return nil, err
+			}
 
-			parameters := make([]*variable.DynamicVariable, 0)
-			for _, parameter := range n.Parameters {
-				valueNode, err := i.EvaluateValueNode(parameter, true)
-				if err != nil {
-					return nil, err
-				}
-				parameters = append(parameters, valueNode)
+			if check.GetType() != variable.BOOLEAN {
+				return nil, n.Condition.ToNode().CreateError(fmt.Sprintf("Expected assertion to result in type %s, got %s", variable.BOOLEAN, check.GetType()), i.source)
 			}
 
-			_, err := paragraph.Execute(parameters...)
+			if !check.GetValueBoolean() {
+				condition := n.Condition.ToNode()
+				return nil, condition.CreateError(fmt.Sprintf("Assertion failed: %s", i.source.Content[condition.Start:condition.Start+condition.Length]), i.source)
+			}
+		default:
+			return nil, statement.ToNode().CreateError("Unsupported statement node.", i.source)
+		}
+	}
+
+	return nil, nil
+}
+
+// Run the body of the statement that is being run in the frame.
+func (i *Interpreter) evaluateBody(f *Frame, body *nodes.StatementsNode) (*variable.DynamicVariable, error) {
+	f.Entered = true
+	defer func() {
+		f.Entered = false
+	}()
+
+	return i.EvaluateStatementsNode(body)
+}
+
+// Run the body of a loop with its loop variable. If the loop variable is nil, it is already declared.
+func (i *Interpreter) evaluateLoopBody(f *Frame, body *nodes.StatementsNode, loopVariable *Variable) (*variable.DynamicVariable, error) {
+	if loopVariable != nil {
+		i.Variables.PushVariable(loopVariable, false)
+	}
+
+	result, err := i.evaluateBody(f, body)
+	i.Variables.PopVariable(false)
+
+	return result, err
+}
+
+func (i *Interpreter) evaluateIfStatementNode(f *Frame, n *nodes.IfStatementNode, resume *Frame) (*variable.DynamicVariable, error) {
+	if resume != nil {
+		if resume.Branch < 0 || resume.Branch >= len(n.Conditions) {
+			return nil, fmt.Errorf("%w: branch %d does not exist", ErrInvalidFrames, resume.Branch)
+		}
+
+		f.Branch = resume.Branch
+		return i.evaluateBody(f, &n.Conditions[f.Branch].StatementsNode)
+	}
+
+	for idx, branch := range n.Conditions {
+		check := true
+
+		if branch.Condition != nil {
+			branchCheck, err := i.EvaluateValueNode(*branch.Condition, true)
 			if err != nil {
 				return nil, err
 			}
-		case *nodes.FunctionReturnNode:
-			value, err := i.EvaluateValueNode(n.Value, true)
-			return value, err
+
+			if branchCheck.GetType() != variable.BOOLEAN {
+				return nil, branch.ToNode().CreateError(fmt.Sprintf("Expected condition to result in type %s, got %s", variable.BOOLEAN, branchCheck.GetType()), i.source)
+			}
+
+			check = branchCheck.GetValueBoolean()
+		}
+
+		if check {
+			f.Branch = idx
+			return i.evaluateBody(f, &n.Conditions[idx].StatementsNode)
+		}
+	}
+
+	return nil, nil
+}
+
+func (i *Interpreter) evaluateWhileStatementNode(f *Frame, n *nodes.WhileStatementNode, resume *Frame) (*variable.DynamicVariable, error) {
+	if resume != nil {
+		result, err := i.evaluateBody(f, &n.StatementsNode)
+		if result != nil || err != nil {
+			return result, err
+		}
+	}
+
+	for {
+		if err := i.checkpoint(n); err != nil {
+			return nil, err
+		}
+
+		branchCheck, err := i.EvaluateValueNode(*n.Condition, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if branchCheck.GetType() != variable.BOOLEAN {
+			return nil, n.ToNode().CreateError(fmt.Sprintf("Expected condition to result in type %s, got %s", variable.BOOLEAN, branchCheck.GetType()), i.source)
+		}
+
+		check := branchCheck.GetValueBoolean()
+
+		if !check {
+			break
+		}
+
+		result, err := i.evaluateBody(f, &n.StatementsNode)
+
+		if result != nil || err != nil {
+			return result, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (i *Interpreter) evaluateForEveryArrayStatementNode(f *Frame, n *nodes.ForEveryArrayStatementNode, resume *Frame) (*variable.DynamicVariable, error) {
+	if !i.Variables.Has(n.Identifier, true) {
+		return nil, n.ToNode().CreateError(i.variableNotFound(n.Identifier), i.source)
+	}
+
+	v := i.Variables.Get(n.Identifier, true)
+
+	if resume != nil {
+		if !i.Variables.Has(n.VariableName, true) {
+			return nil, fmt.Errorf("%w: loop variable '%s' does not exist", ErrInvalidFrames, n.VariableName)
+		}
+
+		f.Keys = slices.Clone(resume.Keys)
+		f.Characters = resume.Characters
+
+		result, err := i.evaluateLoopBody(f, &n.StatementsNode, nil)
+		if result != nil || err != nil {
+			return result, err
+		}
+	} else {
+		if v.GetType().IsArray() {
+			if v.GetType().AsBaseType() != n.VariableType {
+				return nil, n.ToNode().CreateError(fmt.Sprintf("Expected loop variable to be type %s, got %s", v.GetType().AsBaseType(), n.VariableType), i.source)
+			}
+		} else if v.GetType() == variable.STRING {
+			if variable.CHARACTER != n.VariableType {
+				return nil, n.ToNode().CreateError(fmt.Sprintf("Expected loop variable to be type %s, got %s", variable.CHARACTER, n.VariableType), i.source)
+			}
+		} else {
+			return nil, n.ToNode().CreateError(fmt.Sprintf("Expected an array variable, got type %s", v.GetType()), i.source)
+		}
+
+		if i.Variables.Has(n.VariableName, true) {
+			return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' already exists.", n.VariableName), i.source)
+		}
+
+		if v.GetType() == variable.STRING {
+			f.Characters = v.GetValueString()
+		} else {
+			f.Keys = make([]int, 0, len(v.GetValueDictionary()))
+			for k := range v.GetValueDictionary() {
+				f.Keys = append(f.Keys, k)
+			}
+			slices.Sort(f.Keys)
+		}
+	}
+
+	if v.GetType() == variable.STRING {
+		for f.Characters != "" {
+			c, size := utf8.DecodeRuneInString(f.Characters)
+			f.Characters = f.Characters[size:]
+
+			variable := &Variable{
+				Name:            n.VariableName,
+				DynamicVariable: variable.NewRawCharacterVariable(string(c)),
+				Constant:        true,
+			}
+
+			result, err := i.evaluateLoopBody(f, &n.StatementsNode, variable)
+			if result != nil || err != nil {
+				return result, err
+			}
+		}
+		return nil, nil
+	}
+
+	for len(f.Keys) > 0 {
+		value := v.GetValueDictionary()[f.Keys[0]]
+		f.Keys = f.Keys[1:]
+
+		var loopValue *variable.DynamicVariable
+		switch v.GetType() {
+		case variable.STRING_ARRAY:
+			loopValue = variable.NewRawStringVariable(value.GetValueString())
+		case variable.BOOLEAN_ARRAY:
+			loopValue = variable.NewBooleanVariable(value.GetValueBoolean())
+		case variable.NUMBER_ARRAY:
+			loopValue = variable.NewNumberVariable(value.GetValueNumber())
 		default:
-			return nil, statement.ToNode().CreateError("Unsupported statement node.", i.source)
+			return nil, nil
+		}
+
+		variable := &Variable{
+			Name:            n.VariableName,
+			DynamicVariable: loopValue,
+			Constant:        true,
+		}
+
+		result, err := i.evaluateLoopBody(f, &n.StatementsNode, variable)
+		if result != nil || err != nil {
+			return result, err
 		}
 	}
 
 	return nil, nil
 }
+
+func (i *Interpreter) evaluateForEveryRangeStatementNode(f *Frame, n *nodes.ForEveryRangeStatementNode, resume *Frame) (*variable.DynamicVariable, error) {
+	if resume != nil {
+		if !i.Variables.Has(n.VariableName, true) {
+			return nil, fmt.Errorf("%w: loop variable '%s' does not exist", ErrInvalidFrames, n.VariableName)
+		}
+
+		f.Current = resume.Current
+		f.End = resume.End
+		f.Forwards = resume.Forwards
+	} else {
+		if i.Variables.Has(n.VariableName, true) {
+			return nil, n.ToNode().CreateError(fmt.Sprintf("Variable '%s' already exists.", n.VariableName), i.source)
+		}
+
+		fromRange, err := i.EvaluateValueNode(n.RangeStart, true)
+		if err != nil {
+			return nil, err
+		}
+		if fromRange.GetType() != variable.NUMBER {
+			return nil, n.RangeStart.ToNode().CreateError(fmt.Sprintf("Expected a number type, got %s", fromRange.GetType()), i.source)
+		}
+
+		toRange, err := i.EvaluateValueNode(n.RangeEnd, true)
+		if err != nil {
+			return nil, err
+		}
+		if toRange.GetType() != variable.NUMBER {
+			return nil, n.RangeEnd.ToNode().CreateError(fmt.Sprintf("Expected a number type, got %s", toRange.GetType()), i.source)
+		}
+
+		f.Current = fromRange.GetValueNumber()
+		f.End = toRange.GetValueNumber()
+		f.Forwards = f.End >= f.Current
+	}
+
+	step := 1.0
+	if !f.Forwards {
+		step = -1.0
+	}
+
+	if resume != nil {
+		result, err := i.evaluateLoopBody(f, &n.StatementsNode, nil)
+		if result != nil || err != nil {
+			return result, err
+		}
+
+		f.Current += step
+	}
+
+	for {
+		if f.Forwards && f.Current > f.End {
+			break
+		} else if !f.Forwards && f.Current < f.End {
+			break
+		}
+
+		variable := &Variable{
+			Name:            n.VariableName,
+			DynamicVariable: variable.NewNumberVariable(f.Current),
+			Constant:        true,
+		}
+
+		result, err := i.evaluateLoopBody(f, &n.StatementsNode, variable)
+		if result != nil || err != nil {
+			return result, err
+		}
+
+		f.Current += step
+	}
+
+	return nil, nil
+}
+
+func (i *Interpreter) evaluateFunctionCallNode(f *Frame, n *nodes.FunctionCallNode, resume *Frame) error {
+	paragraphIndex := slices.IndexFunc(i.Paragraphs, func(p *Paragraph) bool { return p.Name == n.Identifier })
+	if paragraphIndex == -1 {
+		return n.ToNode().CreateError(fmt.Sprintf("Paragraph '%s' not found%s", n.Identifier, i.suggestParagraph(n.Identifier)), i.source)
+	}
+
+	paragraph := i.Paragraphs[paragraphIndex]
+
+	parameters := make([]*variable.DynamicVariable, 0)
+	if resume == nil {
+		for _, parameter := range n.Parameters {
+			valueNode, err := i.EvaluateValueNode(parameter, true)
+			if err != nil {
+				return err
+			}
+			parameters = append(parameters, valueNode)
+		}
+	}
+
+	f.Entered = true
+	defer func() {
+		f.Entered = false
+	}()
+
+	if resume != nil {
+		scope, err := i.nextResumeScope()
+		if err != nil {
+			return err
+		}
+
+		i.Variables.Locals.Push(scope)
+		_, err = paragraph.evaluateBody()
+		return err
+	}
+
+	_, err := paragraph.Execute(parameters...)
+	return err
+}
@@ -0,0 +1,57 @@
+package celestia
+
+import (
+	"fmt"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// Returns the names of every paragraph in the report.
+func (i *Interpreter) paragraphNames() []string {
+	names := make([]string, 0, len(i.Paragraphs))
+	for _, paragraph := range i.Paragraphs {
+		names = append(names, paragraph.Name)
+	}
+	return names
+}
+
+// Create a ". Did you mean ...?" hint for a misspelled name, or an empty string if
+// none of the candidates are close enough. The hint carries its own punctuation, so
+// messages without a suggestion are left as is.
+func didYouMean(name string, candidates ...[]string) string {
+	names := make([]string, 0)
+	for _, c := range candidates {
+		names = append(names, c...)
+	}
+
+	suggestion, ok := luna.ClosestString(name, names)
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf(". Did you mean '%s'?", suggestion)
+}
+
+// Suggests a variable or paragraph name that is close to the unknown identifier.
+func (i *Interpreter) suggestIdentifier(name string, local bool) string {
+	return didYouMean(name, i.Variables.Names(local), i.paragraphNames())
+}
+
+// Suggests a variable name that is close to the unknown variable.
+func (i *Interpreter) suggestVariable(name string, local bool) string {
+	return didYouMean(name, i.Variables.Names(local))
+}
+
+// Suggests a paragraph name that is close to the unknown paragraph.
+func (i *Interpreter) suggestParagraph(name string) string {
+	return didYouMean(name, i.paragraphNames())
+}
+
+// Creates the error message for a variable that does not exist.
+func (i *Interpreter) variableNotFound(name string) string {
+	hint := i.suggestVariable(name, true)
+	if hint == "" {
+		hint = "."
+	}
+	return fmt.Sprintf("Variable '%s' does not exist%s", name, hint)
+}
@@ -14,6 +14,16 @@ import (
 
 func (i *Interpreter) EvaluateValueNode(n node.DynamicNode, local bool) (*variable.DynamicVariable, error) {
 	if literalNode, ok := n.(*nodes.LiteralNode); ok {
+		// The AST is shared between runs, so a dictionary literal
+		// must never hand out its own map.
+		if literalNode.DynamicVariable.GetType().IsArray() {
+			dictionary := variable.NewDictionaryVariable(literalNode.DynamicVariable.GetType())
+			for idx, value := range literalNode.DynamicVariable.GetValueDictionary() {
+				dictionary.GetValueDictionary()[idx] = value.Clone()
+			}
+			return dictionary, nil
+		}
+
 		return literalNode.DynamicVariable.Clone(), nil
 	}
 
@@ -31,6 +41,13 @@ func (i *Interpreter) EvaluateValueNode(n node.DynamicNode, local bool) (*variab
 		return dictionary, nil
 	}
 
+	if _, ok := n.(*nodes.MoreInputNode); ok {
+		if i.MoreInput != nil {
+			return variable.NewBooleanVariable(i.MoreInput()), nil
+		}
+		return variable.NewBooleanVariable(!i.endOfInput), nil
+	}
+
 	if identifierNode, ok := n.(*nodes.IdentifierNode); ok {
 		if variable := i.Variables.Get(identifierNode.Identifier, local); variable != nil {
 			return variable.DynamicVariable.Clone(), nil
@@ -44,13 +61,13 @@ func (i *Interpreter) EvaluateValueNode(n node.DynamicNode, local bool) (*variab
 			return value, err
 		}
 
-		return nil, lunaErrors.NewParseError(fmt.Sprintf("Unknown identifier (%s)", identifierNode.Identifier), i.source, identifierNode.Start)
+		return nil, lunaErrors.NewParseError(fmt.Sprintf("Unknown identifier (%s)%s", identifierNode.Identifier, i.suggestIdentifier(identifierNode.Identifier, local)), i.source, identifierNode.Start)
 	}
 
 	if callNode, ok := n.(*nodes.FunctionCallNode); ok {
 		paragraphIndex := slices.IndexFunc(i.Paragraphs, func(p *Paragraph) bool { return p.Name == callNode.Identifier })
 		if paragraphIndex == -1 {
-			return nil, lunaErrors.NewParseError(fmt.Sprintf("Unknown paragraph (%s)", callNode.Identifier), i.source, callNode.Start)
+			return nil, lunaErrors.NewParseError(fmt.Sprintf("Unknown paragraph (%s)%s", callNode.Identifier, i.suggestParagraph(callNode.Identifier)), i.source, callNode.Start)
 		}
 
 		paragraph := i.Paragraphs[paragraphIndex]
@@ -76,7 +93,7 @@ func (i *Interpreter) EvaluateValueNode(n node.DynamicNode, local bool) (*variab
 	if identifierNode, ok := n.(*nodes.DictionaryIdentifierNode); ok {
 		v := i.Variables.Get(identifierNode.Identifier, local)
 		if v == nil {
-			return nil, lunaErrors.NewParseError(fmt.Sprintf("Unknown identifier (%s)", identifierNode.Identifier), i.source, identifierNode.Start)
+			return nil, lunaErrors.NewParseError(fmt.Sprintf("Unknown identifier (%s)%s", identifierNode.Identifier, i.suggestVariable(identifierNode.Identifier, local)), i.source, identifierNode.Start)
 		}
 		if !v.GetType().IsArray() && v.GetType() != variable.STRING {
 			return nil, lunaErrors.NewParseError(fmt.Sprintf("Invalid non-dicionary identifier (%s)", identifierNode.Identifier), i.source, identifierNode.Start)
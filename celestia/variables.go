@@ -124,3 +124,22 @@ func (m *VariableManager) Get(name string, local bool) *Variable {
 func (m *VariableManager) Has(name string, local bool) bool {
 	return m.Get(name, local) != nil
 }
+
+// Returns the names of every variable that is currently reachable.
+func (m *VariableManager) Names(local bool) []string {
+	names := make([]string, 0, m.Globals.Len())
+
+	for idx := 0; idx < m.Globals.Len(); idx += 1 {
+		names = append(names, m.Globals.PeekAt(idx).Name)
+	}
+
+	if local && m.ScopeDepth() > 0 {
+		current := m.Locals.Peek()
+
+		for idx := 0; idx < current.Len(); idx += 1 {
+			names = append(names, current.PeekAt(idx).Name)
+		}
+	}
+
+	return names
+}
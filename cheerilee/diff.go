@@ -0,0 +1,72 @@
+package cheerilee
+
+import (
+	"strings"
+)
+
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Create a line-based diff between the expected and the actual output.
+//
+// Lines only in the expected output are prefixed with '-', and lines only in
+// the actual output are prefixed with '+'.
+func Diff(expected string, actual string) string {
+	a := splitLines(expected)
+	b := splitLines(actual)
+
+	// Longest common subsequence of lines
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var builder strings.Builder
+	writeLine := func(prefix string, line string) {
+		builder.WriteString(prefix)
+		if strings.HasSuffix(line, "\n") {
+			builder.WriteString(line)
+		} else {
+			builder.WriteString(line)
+			builder.WriteString("\n\\ No newline at end of output\n")
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			writeLine("  ", a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			writeLine("- ", a[i])
+			i++
+		default:
+			writeLine("+ ", b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		writeLine("- ", a[i])
+	}
+	for ; j < len(b); j++ {
+		writeLine("+ ", b[j])
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,83 @@
+package cheerilee
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	TestFileSuffix   = "_test.fim"
+	OutputFileSuffix = ".out"
+	InputFileSuffix  = ".in"
+)
+
+// Returns the path of the golden output file of a report.
+func OutputFile(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + OutputFileSuffix
+}
+
+// Returns the path of the scripted input file of a report.
+func InputFile(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + InputFileSuffix
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// Checks if the report should be run by the test runner.
+//
+// These are either reports ending with '_test.fim', or reports that have a
+// golden '.out' file beside it.
+func IsTestFile(path string) bool {
+	if strings.HasSuffix(path, TestFileSuffix) {
+		return true
+	}
+	if filepath.Ext(path) != ".fim" {
+		return false
+	}
+
+	return fileExists(OutputFile(path))
+}
+
+// Find every test report in the given files and directories.
+func Discover(paths ...string) ([]string, error) {
+	files := make([]string, 0)
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			if !slices.Contains(files, path) {
+				files = append(files, path)
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !IsTestFile(path) {
+				return nil
+			}
+
+			if !slices.Contains(files, path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,98 @@
+package cheerilee
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// Write the results as a JUnit XML report.
+func WriteJUnit(w io.Writer, suites []*Suite) error {
+	report := junitTestSuites{
+		Suites: make([]junitTestSuite, 0, len(suites)),
+	}
+
+	var total time.Duration
+	for _, suite := range suites {
+		junitSuite := junitTestSuite{
+			Name:     suite.File,
+			Tests:    len(suite.Cases),
+			Failures: suite.Failures(),
+			Time:     junitTime(suite.Duration),
+			Cases:    make([]junitTestCase, 0, len(suite.Cases)),
+		}
+
+		for _, c := range suite.Cases {
+			name := c.Paragraph
+			if name == "" {
+				name = "main"
+			}
+
+			junitCase := junitTestCase{
+				Name:      name,
+				ClassName: c.File,
+				Time:      junitTime(c.Duration),
+			}
+			if !c.Passed {
+				junitCase.Failure = &junitFailure{
+					Message:  c.Message,
+					Contents: c.Details,
+				}
+			}
+
+			junitSuite.Cases = append(junitSuite.Cases, junitCase)
+		}
+
+		report.Tests += junitSuite.Tests
+		report.Failures += junitSuite.Failures
+		total += suite.Duration
+		report.Suites = append(report.Suites, junitSuite)
+	}
+	report.Time = junitTime(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
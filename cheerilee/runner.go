@@ -0,0 +1,189 @@
+package cheerilee
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"git.jaezmien.com/Jaezmien/fim"
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+)
+
+// A Case is the result of a single test.
+type Case struct {
+	// The report the test belongs to
+	File string
+	// The name of the test paragraph, or empty if the main paragraphs are tested
+	Paragraph string
+
+	Passed bool
+	// Why the test failed
+	Message string
+	// Extra details on why the test failed, such as the output diff
+	Details string
+
+	Duration time.Duration
+}
+
+func (c *Case) Name() string {
+	if c.Paragraph == "" {
+		return c.File
+	}
+	return c.File + ": " + c.Paragraph
+}
+
+func (c *Case) fail(message string, details string) {
+	c.Passed = false
+	c.Message = message
+	c.Details = details
+}
+
+// A Suite is every test case of a single report.
+type Suite struct {
+	File  string
+	Cases []*Case
+
+	Duration time.Duration
+}
+
+func (s *Suite) Failures() int {
+	failures := 0
+	for _, c := range s.Cases {
+		if !c.Passed {
+			failures++
+		}
+	}
+	return failures
+}
+
+// Run the program with its output and prompt redirected.
+func runProgram(program *fim.Program, entry string, input string) (*bytes.Buffer, error) {
+	output := &bytes.Buffer{}
+	prompt, more := fim.InputPrompt(strings.NewReader(input), output)
+
+	_, err := program.Run(context.Background(), fim.RunOptions{
+		Stdout:    output,
+		Stderr:    output,
+		Prompt:    prompt,
+		MoreInput: more,
+		Entry:     entry,
+	})
+
+	return output, err
+}
+
+func readOptionalFile(path string) (string, bool, error) {
+	if !fileExists(path) {
+		return "", false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+// Run the main paragraphs, and compare its output to the golden output if there is one.
+func runMain(c *Case, program *fim.Program, input string, expected string, hasExpected bool) {
+	output, err := runProgram(program, "", input)
+	if err != nil {
+		c.fail("Report raised an error", err.Error())
+		return
+	}
+
+	if hasExpected && output.String() != expected {
+		c.fail("Output does not match "+OutputFile(c.File), Diff(expected, output.String()))
+		return
+	}
+
+	c.Passed = true
+}
+
+// Run a single test paragraph.
+func runParagraph(c *Case, program *fim.Program, paragraph *nodes.FunctionNode, input string) {
+	if len(paragraph.Parameters) > 0 {
+		c.fail("Test paragraphs cannot have parameters", "")
+		return
+	}
+
+	output, err := runProgram(program, paragraph.Name, input)
+	if err != nil {
+		c.fail("Test raised an error", strings.TrimSpace(output.String()+"\n"+err.Error()))
+		return
+	}
+
+	c.Passed = true
+}
+
+// Run every test case of a report.
+//
+// Test paragraphs are run on their own fresh interpreter, and fail if they raise
+// an error, such as a false assertion. If the report has a golden '.out' file, the main paragraphs are run
+// and their output must match it. Prompts are answered with each line of the
+// '.in' file, if there is one.
+func RunFile(path string) *Suite {
+	suite := &Suite{
+		File:  path,
+		Cases: make([]*Case, 0),
+	}
+
+	start := time.Now()
+	defer func() { suite.Duration = time.Since(start) }()
+
+	failFile := func(message string, details string) *Suite {
+		c := &Case{File: path}
+		c.fail(message, details)
+		suite.Cases = append(suite.Cases, c)
+		return suite
+	}
+
+	rawSource, err := os.ReadFile(path)
+	if err != nil {
+		return failFile("Could not load report", err.Error())
+	}
+	source := string(rawSource)
+
+	input, _, err := readOptionalFile(InputFile(path))
+	if err != nil {
+		return failFile("Could not load input", err.Error())
+	}
+	expected, hasExpected, err := readOptionalFile(OutputFile(path))
+	if err != nil {
+		return failFile("Could not load output", err.Error())
+	}
+
+	program, err := fim.Compile(source, fim.CompileOptions{})
+	if err != nil {
+		return failFile("Could not parse report", err.Error())
+	}
+
+	hasTestParagraphs := false
+	for _, n := range program.Report().Body {
+		paragraph, ok := n.(*nodes.FunctionNode)
+		if !ok || !paragraph.IsTest() {
+			continue
+		}
+		hasTestParagraphs = true
+
+		c := &Case{File: path, Paragraph: paragraph.Name}
+		caseStart := time.Now()
+		runParagraph(c, program, paragraph, input)
+		c.Duration = time.Since(caseStart)
+
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if hasExpected || !hasTestParagraphs {
+		c := &Case{File: path}
+		caseStart := time.Now()
+		runMain(c, program, input, expected, hasExpected)
+		c.Duration = time.Since(caseStart)
+
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	return suite
+}
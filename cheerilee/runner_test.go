@@ -0,0 +1,181 @@
+package cheerilee
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const greetingReport = `Dear Princess Celestia: Greetings!
+Today I learned how to greet!
+	Did you know that Applejack is a word?
+	I asked Applejack: "Name? ".
+	I said "Hello " plus Applejack!
+That's all about how to greet.
+Your faithful student, Cheerilee.`
+
+func TestDiscover(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"greet.fim":     greetingReport,
+		"greet.out":     "",
+		"math_test.fim": "",
+		"untested.fim":  "",
+		"untested.in":   "",
+		"readme.txt":    "",
+	})
+
+	files, err := Discover(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	names := make([]string, 0)
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+	assert.ElementsMatch(t, []string{"greet.fim", "math_test.fim"}, names)
+}
+
+func TestRunFile(t *testing.T) {
+	t.Run("should compare output with scripted input", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"greet.fim": greetingReport,
+			"greet.in":  "Twilight\n",
+			"greet.out": "Name? Hello Twilight\n",
+		})
+
+		suite := RunFile(filepath.Join(dir, "greet.fim"))
+		if !assert.Len(t, suite.Cases, 1) {
+			return
+		}
+		assert.True(t, suite.Cases[0].Passed, suite.Cases[0].Details)
+	})
+
+	t.Run("should show a diff on mismatched output", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"greet.fim": greetingReport,
+			"greet.in":  "Rarity\n",
+			"greet.out": "Name? Hello Twilight\n",
+		})
+
+		suite := RunFile(filepath.Join(dir, "greet.fim"))
+		if !assert.Len(t, suite.Cases, 1) {
+			return
+		}
+		assert.False(t, suite.Cases[0].Passed)
+		assert.Equal(t, "- Name? Hello Twilight\n+ Name? Hello Rarity\n", suite.Cases[0].Details)
+	})
+
+	t.Run("should fail when running out of input", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"greet.fim": greetingReport,
+			"greet.out": "Name? Hello \n",
+		})
+
+		suite := RunFile(filepath.Join(dir, "greet.fim"))
+		if !assert.Len(t, suite.Cases, 1) {
+			return
+		}
+		assert.False(t, suite.Cases[0].Passed)
+		assert.Contains(t, suite.Cases[0].Details, "Ran out of scripted input")
+	})
+
+	t.Run("should run test paragraphs", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"math_test.fim": `Dear Princess Celestia: Math!
+			I learned how to sum up using the number A, the number B to get a number!
+				Then you get A plus B!
+			That's all about how to sum up.
+			I learned how to test summing!
+				Did you know that Total is the number how to sum up using 1, 2?
+			That's all about how to test summing.
+			I learned how to test missing paragraphs!
+				I remembered how to fly.
+			That's all about how to test missing paragraphs.
+			Your faithful student, Cheerilee.`,
+		})
+
+		suite := RunFile(filepath.Join(dir, "math_test.fim"))
+		if !assert.Len(t, suite.Cases, 2) {
+			return
+		}
+		assert.Equal(t, "how to test summing", suite.Cases[0].Paragraph)
+		assert.True(t, suite.Cases[0].Passed, suite.Cases[0].Details)
+		assert.Equal(t, "how to test missing paragraphs", suite.Cases[1].Paragraph)
+		assert.False(t, suite.Cases[1].Passed)
+		assert.Equal(t, 1, suite.Failures())
+	})
+
+	t.Run("should fail test paragraphs on a false assertion", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"assert_test.fim": `Dear Princess Celestia: Assertions!
+			I learned how to test assertions!
+				Did you know that Total is the number 1 plus 1?
+				I made sure that Total is 2.
+				I made sure that Total is 3.
+			That's all about how to test assertions.
+			Your faithful student, Cheerilee.`,
+		})
+
+		suite := RunFile(filepath.Join(dir, "assert_test.fim"))
+		if !assert.Len(t, suite.Cases, 1) {
+			return
+		}
+		assert.False(t, suite.Cases[0].Passed)
+		assert.Contains(t, suite.Cases[0].Details, "Assertion failed: Total is 3")
+	})
+
+	t.Run("should fail on an invalid report", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"broken_test.fim": `Dear Princess Celestia: Broken!`,
+		})
+
+		suite := RunFile(filepath.Join(dir, "broken_test.fim"))
+		if !assert.Len(t, suite.Cases, 1) {
+			return
+		}
+		assert.False(t, suite.Cases[0].Passed)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	assert.Equal(t, "  a\n- b\n+ c\n  d\n", Diff("a\nb\nd\n", "a\nc\nd\n"))
+	assert.Equal(t, "  a\n- b\n+ b\n\\ No newline at end of output\n", Diff("a\nb\n", "a\nb"))
+}
+
+func TestWriteJUnit(t *testing.T) {
+	suites := []*Suite{
+		{
+			File: "math_test.fim",
+			Cases: []*Case{
+				{File: "math_test.fim", Paragraph: "how to test summing", Passed: true},
+				{File: "math_test.fim", Paragraph: "how to test dividing", Message: "Test raised an error", Details: "Division by zero"},
+			},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	if !assert.NoError(t, WriteJUnit(buffer, suites)) {
+		return
+	}
+
+	output := buffer.String()
+	assert.True(t, strings.HasPrefix(output, "<?xml"))
+	assert.Contains(t, output, `<testsuites tests="2" failures="1"`)
+	assert.Contains(t, output, `<testcase name="how to test summing" classname="math_test.fim"`)
+	assert.Contains(t, output, `<failure message="Test raised an error">Division by zero</failure>`)
+}
@@ -0,0 +1,37 @@
+package cheerilee
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Write a human-readable summary of the results.
+//
+// Passing cases are only listed if verbose is set.
+func WriteSummary(w io.Writer, suites []*Suite, verbose bool) {
+	passed, failed := 0, 0
+
+	for _, suite := range suites {
+		for _, c := range suite.Cases {
+			if c.Passed {
+				passed++
+				if verbose {
+					fmt.Fprintf(w, "ok   %s (%.3fs)\n", c.Name(), c.Duration.Seconds())
+				}
+				continue
+			}
+
+			failed++
+			fmt.Fprintf(w, "FAIL %s (%.3fs)\n", c.Name(), c.Duration.Seconds())
+			fmt.Fprintf(w, "     %s\n", c.Message)
+			if c.Details != "" {
+				for _, line := range splitLines(c.Details) {
+					fmt.Fprintf(w, "     %s\n", strings.TrimRight(line, "\n"))
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "%d passed, %d failed\n", passed, failed)
+}
@@ -0,0 +1,189 @@
+//go:build !js
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim"
+	"git.jaezmien.com/Jaezmien/fim/luna/aprint"
+	"git.jaezmien.com/Jaezmien/fim/spike"
+)
+
+// fim ast [-format tree|json] <file | - | -e source>
+func astCommand(args []string) int {
+	flags := flag.NewFlagSet("ast", flag.ExitOnError)
+	addInlineFlag(flags)
+	formatFlag := flags.String("format", "tree", "Output format: tree or json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim ast [flags] <file | - | -e source>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *formatFlag != "tree" && *formatFlag != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown format '%s'\n", *formatFlag)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	source, _, err := loadSource(flags, os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	program, err := fim.Compile(source, fim.CompileOptions{})
+	if err != nil {
+		return reportError(os.Stderr, err)
+	}
+
+	if err := printAST(os.Stdout, spike.Serialize(program.Report()), *formatFlag); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return EXIT_RUNTIME_ERROR
+	}
+
+	return EXIT_SUCCESS
+}
+
+// Print a node that was converted with spike.Serialize.
+func printAST(w io.Writer, serialized any, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(serialized)
+	}
+
+	epf := aprint.New(4, " ", aprint.LEFT_ALIGN)
+	epf.SetAlignment(1, aprint.RIGHT_ALIGN)
+	epf.SetAlignment(2, aprint.RIGHT_ALIGN)
+	epf.SetDelimeter(0, "  ")
+	epf.SetDelimeter(2, "  ")
+
+	epf.Add("NODE", "START", "LENGTH", "FIELDS")
+	addTreeNode(epf, serialized.(map[string]any), "", "", "")
+
+	for _, line := range strings.Split(epf.String(), "\n") {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Checks if the serialized value is a node.
+func isSerializedNode(value any) bool {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = object["Start"]
+	return ok
+}
+
+// Checks if the serialized value is a node, or a list with a node in it.
+func hasSerializedNode(value any) bool {
+	if list, ok := value.([]any); ok {
+		return slices.ContainsFunc(list, isSerializedNode)
+	}
+	return isSerializedNode(value)
+}
+
+// Add a node as a row, followed by its child nodes.
+//
+// The node's other fields are written in the same row. The prefix is the
+// tree drawn before the node's name, and indent is the tree drawn before its children.
+func addTreeNode(epf *aprint.AlignedPrint, object map[string]any, label string, prefix string, indent string) {
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	fields := make([]string, 0)
+	type child struct {
+		label  string
+		object map[string]any
+	}
+	children := make([]child, 0)
+
+	for _, name := range names {
+		value := object[name]
+
+		switch name {
+		case "Type", "Start", "Length":
+			continue
+		}
+
+		if !hasSerializedNode(value) {
+			fields = append(fields, name+"="+formatTreeValue(value))
+			continue
+		}
+
+		if list, ok := value.([]any); ok {
+			for idx, item := range list {
+				itemLabel := fmt.Sprintf("%s[%d]", name, idx)
+				if isSerializedNode(item) {
+					children = append(children, child{label: itemLabel, object: item.(map[string]any)})
+				} else {
+					fields = append(fields, itemLabel+"="+formatTreeValue(item))
+				}
+			}
+			continue
+		}
+
+		children = append(children, child{label: name, object: value.(map[string]any)})
+	}
+
+	name := strings.TrimPrefix(fmt.Sprint(object["Type"]), "nodes.")
+	if label != "" {
+		name = label + ": " + name
+	}
+	epf.Add(prefix+name, formatTreeValue(object["Start"]), formatTreeValue(object["Length"]), strings.Join(fields, " "))
+
+	for idx, c := range children {
+		if idx+1 == len(children) {
+			addTreeNode(epf, c.object, c.label, indent+"└─ ", indent+"   ")
+		} else {
+			addTreeNode(epf, c.object, c.label, indent+"├─ ", indent+"│  ")
+		}
+	}
+}
+
+// Format a serialized field which is not a node.
+func formatTreeValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, formatTreeValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]any:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		items := make([]string, 0, len(v))
+		for _, name := range names {
+			items = append(items, name+"="+formatTreeValue(v[name]))
+		}
+		return "{" + strings.Join(items, " ") + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
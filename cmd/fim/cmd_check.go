@@ -0,0 +1,47 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"git.jaezmien.com/Jaezmien/fim"
+)
+
+// fim check [flags] <file | - | -e source>
+func checkCommand(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	addInlineFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim check [flags] <file | - | -e source>")
+		fmt.Fprintln(flags.Output(), "Parses the report and sets it up without running it.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	source, _, err := loadSource(flags, os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	return checkReport(source, os.Stderr)
+}
+
+// Parse and set up the report, and return the exit code.
+func checkReport(source string, stderr io.Writer) int {
+	program, err := fim.Compile(source, fim.CompileOptions{})
+	if err != nil {
+		return reportError(stderr, err)
+	}
+
+	if err := program.Check(); err != nil {
+		return reportError(stderr, err)
+	}
+
+	return EXIT_SUCCESS
+}
@@ -0,0 +1,58 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"git.jaezmien.com/Jaezmien/fim"
+	"git.jaezmien.com/Jaezmien/fim/yearling"
+)
+
+// fim doc [-format markdown|html] <file | - | -e source>
+func docCommand(args []string) int {
+	flags := flag.NewFlagSet("doc", flag.ExitOnError)
+	addInlineFlag(flags)
+	formatFlag := flags.String("format", "markdown", "Output format: markdown or html")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim doc [flags] <file | - | -e source>")
+		fmt.Fprintln(flags.Output(), "Prints the documentation of every paragraph, from the comments right above it.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *formatFlag != "markdown" && *formatFlag != "html" {
+		fmt.Fprintf(os.Stderr, "Unknown format '%s'\n", *formatFlag)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	source, _, err := loadSource(flags, os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	program, err := fim.Compile(source, fim.CompileOptions{})
+	if err != nil {
+		return reportError(os.Stderr, err)
+	}
+
+	if err := printDocument(os.Stdout, yearling.New(program.Report(), source), *formatFlag); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return EXIT_RUNTIME_ERROR
+	}
+
+	return EXIT_SUCCESS
+}
+
+func printDocument(w io.Writer, document *yearling.Document, format string) error {
+	if format == "html" {
+		return document.WriteHTML(w)
+	}
+	return document.WriteMarkdown(w)
+}
@@ -0,0 +1,73 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"git.jaezmien.com/Jaezmien/fim/rarity"
+)
+
+// fim fmt [-w] [-check] <files...>
+func formatCommand(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	writeFlag := flags.Bool("w", false, "Write the formatted report back to its file")
+	checkFlag := flags.Bool("check", false, "Exit with a non-zero status if a report is not formatted")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim fmt [flags] <files...>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	status := 0
+	for _, filePath := range flags.Args() {
+		rawSource, err := os.ReadFile(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "An error has occured while trying to load file '%s'\n", filePath)
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			status = 1
+			continue
+		}
+		source := string(rawSource)
+
+		formatted, err := rarity.Format(source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Rarity could not format '%s'...\n", filePath)
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+
+		if *checkFlag {
+			if formatted != source {
+				fmt.Println(filePath)
+				status = 1
+			}
+			continue
+		}
+
+		if *writeFlag {
+			if formatted == source {
+				continue
+			}
+
+			if err := os.WriteFile(filePath, []byte(formatted), 0644); err != nil {
+				fmt.Fprintf(os.Stderr, "An error has occured while trying to write file '%s'\n", filePath)
+				fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+				status = 1
+			}
+			continue
+		}
+
+		fmt.Print(formatted)
+	}
+
+	return status
+}
@@ -0,0 +1,62 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"git.jaezmien.com/Jaezmien/fim/rainbow"
+)
+
+// fim highlight [-format html|ansi] [-standalone] <file | - | -e source>
+func highlightCommand(args []string) int {
+	flags := flag.NewFlagSet("highlight", flag.ExitOnError)
+	addInlineFlag(flags)
+	formatFlag := flags.String("format", "html", "Output format: html or ansi")
+	standaloneFlag := flags.Bool("standalone", false, "Write a whole HTML page with its stylesheet, instead of only the <pre> block")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim highlight [flags] <file | - | -e source>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *formatFlag != "html" && *formatFlag != "ansi" {
+		fmt.Fprintf(os.Stderr, "Unknown format '%s'\n", *formatFlag)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	source, _, err := loadSource(flags, os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	title := "fim"
+	if flags.Lookup("e").Value.String() == "" && flags.Arg(0) != "-" {
+		title = filepath.Base(flags.Arg(0))
+	}
+
+	if err := printHighlight(os.Stdout, source, *formatFlag, *standaloneFlag, title); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return EXIT_RUNTIME_ERROR
+	}
+
+	return EXIT_SUCCESS
+}
+
+func printHighlight(w io.Writer, source string, format string, standalone bool, title string) error {
+	switch {
+	case format == "ansi":
+		return rainbow.WriteANSI(w, source)
+	case standalone:
+		return rainbow.WriteHTMLPage(w, source, title)
+	default:
+		return rainbow.WriteHTML(w, source)
+	}
+}
@@ -0,0 +1,106 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/applejack"
+)
+
+// Parse a comma-separated list of rules.
+func parseLintRules(value string) ([]applejack.Rule, error) {
+	rules := make([]applejack.Rule, 0)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		rule := applejack.Rule(name)
+		if !rule.IsValid() {
+			return nil, fmt.Errorf("Unknown lint rule '%s'", name)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// fim lint [-enable rules] [-disable rules] [-rules] <files...>
+func lintCommand(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	enableFlag := flags.String("enable", "", "Comma-separated list of rules to check. Checks every rule if empty")
+	disableFlag := flags.String("disable", "", "Comma-separated list of rules to skip")
+	rulesFlag := flags.Bool("rules", false, "List every available rule")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim lint [flags] <files...>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *rulesFlag {
+		for _, rule := range applejack.Rules {
+			fmt.Printf("%-24s %s\n", rule, rule.Description())
+		}
+		return 0
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	config := applejack.DefaultConfig()
+
+	enabled, err := parseLintRules(*enableFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if len(enabled) > 0 {
+		for _, rule := range applejack.Rules {
+			config.Disable(rule)
+		}
+		for _, rule := range enabled {
+			config.Enable(rule)
+		}
+	}
+
+	disabled, err := parseLintRules(*disableFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	for _, rule := range disabled {
+		config.Disable(rule)
+	}
+
+	status := 0
+	for _, filePath := range flags.Args() {
+		rawSource, err := os.ReadFile(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "An error has occured while trying to load file '%s'\n", filePath)
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			status = 1
+			continue
+		}
+
+		issues, err := applejack.Lint(string(rawSource), config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Applejack could not lint '%s'...\n", filePath)
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+
+		for _, issue := range issues {
+			fmt.Printf("%s:%s\n", filePath, issue.String())
+			status = 1
+		}
+	}
+
+	return status
+}
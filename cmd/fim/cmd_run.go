@@ -0,0 +1,64 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// fim run [flags] <file | - | -e source> [arguments...]
+func runCommand(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	addInlineFlag(flags)
+	prettyFlag := flags.Bool("pretty", false, "Show the report's title and author before running it")
+	disableAssertionsFlag := flags.Bool("disable-assertions", false, "Skip every assertion statement")
+	inputFlag := flags.String("input", "", "Answer the prompts with each line of a file")
+	recordFlag := flags.String("record", "", "Record every prompt and answer to a file")
+	replayFlag := flags.String("replay", "", "Answer the prompts with a recorded file")
+	sandboxFlag := flags.String("sandbox", "", "Allow the file statements to access this directory")
+	envFlag := flags.String("env", "", "Comma-separated environment variables that the report can read")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim run [flags] <file | - | -e source> [arguments...]")
+		fmt.Fprintln(flags.Output(), "The arguments after the report are passed to its Arguments array.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	source, arguments, err := loadSource(flags, os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	prompt, more, closeFiles, err := createPrompt(inputOptions{
+		Input:  *inputFlag,
+		Record: *recordFlag,
+		Replay: *replayFlag,
+	}, os.Stdin, os.Stdout)
+	if err != nil {
+		closeFiles()
+		fmt.Fprintln(os.Stderr, err)
+		return EXIT_USAGE_ERROR
+	}
+
+	code := runReport(source, reportOptions{
+		Stdin:             os.Stdin,
+		Stdout:            os.Stdout,
+		Stderr:            os.Stderr,
+		Prompt:            prompt,
+		MoreInput:         more,
+		Pretty:            *prettyFlag,
+		DisableAssertions: *disableAssertionsFlag,
+		Sandbox:           *sandboxFlag,
+		Environment:       allowEnvironment(*envFlag, os.LookupEnv),
+		Arguments:         arguments,
+	})
+	if err := closeFiles(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	return code
+}
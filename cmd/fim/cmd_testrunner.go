@@ -0,0 +1,66 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"git.jaezmien.com/Jaezmien/fim/cheerilee"
+)
+
+// fim test [-v] [-junit file] [paths...]
+func testCommand(args []string) int {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	verboseFlag := flags.Bool("v", false, "List passing tests as well")
+	junitFlag := flags.String("junit", "", "Write the results as JUnit XML to this file")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim test [flags] [files or directories...]")
+		fmt.Fprintln(flags.Output(), "Runs every '*_test.fim' report, and every report with a sibling '.out' file.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	files, err := cheerilee.Discover(paths...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cheerilee could not find the tests...")
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	suites := make([]*cheerilee.Suite, 0, len(files))
+	for _, file := range files {
+		suites = append(suites, cheerilee.RunFile(file))
+	}
+
+	cheerilee.WriteSummary(os.Stdout, suites, *verboseFlag)
+
+	if *junitFlag != "" {
+		file, err := os.Create(*junitFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "An error has occured while trying to write file '%s'\n", *junitFlag)
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			return 2
+		}
+		defer file.Close()
+
+		if err := cheerilee.WriteJUnit(file, suites); err != nil {
+			fmt.Fprintf(os.Stderr, "An error has occured while trying to write file '%s'\n", *junitFlag)
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			return 2
+		}
+	}
+
+	for _, suite := range suites {
+		if suite.Failures() > 0 {
+			return 1
+		}
+	}
+	return 0
+}
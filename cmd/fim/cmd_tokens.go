@@ -0,0 +1,155 @@
+//go:build !js
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"git.jaezmien.com/Jaezmien/fim/luna/aprint"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// The current version of the JSON token output.
+// It is only changed when a field is removed or changes its meaning.
+const TOKENS_SCHEMA_VERSION = 1
+
+// The JSON token output
+type tokensOutput struct {
+	Version int           `json:"version"`
+	Tokens  []tokenRecord `json:"tokens"`
+}
+
+// A single token of the JSON and CSV token output
+type tokenRecord struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	// 0-based character index
+	Start  int `json:"start"`
+	Length int `json:"length"`
+	// 1-based line number
+	Line int `json:"line"`
+	// 1-based column number
+	Column int `json:"column"`
+	// 1-based line number right after the end of the token
+	EndLine int `json:"end_line"`
+	// 1-based column number right after the end of the token
+	EndColumn int `json:"end_column"`
+}
+
+// fim tokens [-format table|json|csv] [-trivia] <file | - | -e source>
+func tokensCommand(args []string) int {
+	flags := flag.NewFlagSet("tokens", flag.ExitOnError)
+	addInlineFlag(flags)
+	formatFlag := flags.String("format", "table", "Output format: table, json or csv")
+	triviaFlag := flags.Bool("trivia", false, "Include the whitespace, newline and comment tokens")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim tokens [flags] <file | - | -e source>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *formatFlag != "table" && *formatFlag != "json" && *formatFlag != "csv" {
+		fmt.Fprintf(os.Stderr, "Unknown format '%s'\n", *formatFlag)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	source, _, err := loadSource(flags, os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	var tokens []*token.Token
+	if *triviaFlag {
+		tokens = twilight.ParseWithTrivia(source)
+	} else {
+		tokens = twilight.Parse(source)
+	}
+
+	if err := printTokens(os.Stdout, source, tokens, *formatFlag); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return EXIT_RUNTIME_ERROR
+	}
+
+	return EXIT_SUCCESS
+}
+
+func newTokenRecords(source string, tokens []*token.Token) []tokenRecord {
+	records := make([]tokenRecord, 0, len(tokens))
+
+	file := luna.NewSourceFile(source)
+	for _, t := range tokens {
+		tokenRange := t.Range(file)
+		records = append(records, tokenRecord{
+			Type:      t.Type.String(),
+			Value:     t.Value,
+			Start:     t.Start,
+			Length:    t.Length,
+			Line:      tokenRange.Start.Line,
+			Column:    tokenRange.Start.Column,
+			EndLine:   tokenRange.End.Line,
+			EndColumn: tokenRange.End.Column,
+		})
+	}
+
+	return records
+}
+
+// Print the tokens of the source in the format.
+func printTokens(w io.Writer, source string, tokens []*token.Token, format string) error {
+	records := newTokenRecords(source, tokens)
+
+	switch format {
+	case "json":
+		return json.NewEncoder(w).Encode(tokensOutput{
+			Version: TOKENS_SCHEMA_VERSION,
+			Tokens:  records,
+		})
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"type", "value", "start", "length", "line", "column", "end_line", "end_column"})
+		for _, record := range records {
+			writer.Write([]string{
+				record.Type,
+				record.Value,
+				strconv.Itoa(record.Start),
+				strconv.Itoa(record.Length),
+				strconv.Itoa(record.Line),
+				strconv.Itoa(record.Column),
+				strconv.Itoa(record.EndLine),
+				strconv.Itoa(record.EndColumn),
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	epf := aprint.New(5, " ", aprint.LEFT_ALIGN)
+	epf.SetAlignment(0, aprint.RIGHT_ALIGN)
+	epf.SetAlignment(3, aprint.RIGHT_ALIGN)
+	epf.SetDelimeter(3, " -> ")
+
+	for idx, record := range records {
+		epf.Add(
+			strconv.Itoa(idx+1)+".",
+			strconv.Itoa(record.Start+1)+":"+strconv.Itoa(record.Start+1+record.Length),
+			strconv.Itoa(record.Line)+":"+strconv.Itoa(record.Column),
+			strconv.Quote(record.Value),
+			record.Type,
+		)
+	}
+
+	_, err := fmt.Fprintln(w, epf.String())
+	return err
+}
@@ -0,0 +1,265 @@
+//go:build !js
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+var BuildVersion = "unknown"
+
+// Exit codes of the command line interpreter
+const (
+	EXIT_SUCCESS       = 0
+	EXIT_RUNTIME_ERROR = 1
+	EXIT_USAGE_ERROR   = 2
+	EXIT_PARSE_ERROR   = 3
+	EXIT_SETUP_ERROR   = 4
+)
+
+type reportOptions struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Answers the prompts of the report. If nil, the prompts are read from Stdin
+	Prompt fim.Prompt
+	// Reports whether Prompt has more input to read
+	MoreInput fim.MoreInput
+
+	Pretty            bool
+	DisableAssertions bool
+	// Directory that the file statements are confined to. If empty, file access is disabled
+	Sandbox string
+	// Environment variables that the report is allowed to read, and their values
+	Environment map[string]string
+	// Values of the report's Arguments array
+	Arguments []string
+}
+
+// Files where the prompt answers are read from or written to
+type inputOptions struct {
+	// Answers the prompts with each line of the file
+	Input string
+	// Stores every prompt and answer in the file
+	Record string
+	// Answers the prompts with the ones stored by Record
+	Replay string
+}
+
+// Create the prompt of the report from the input options, and the check for
+// whether it has more input to read.
+//
+// The returned function closes every file that was opened.
+func createPrompt(options inputOptions, stdin io.Reader, stdout io.Writer) (fim.Prompt, fim.MoreInput, func() error, error) {
+	files := make([]*os.File, 0)
+	closeFiles := func() error {
+		var err error
+		for _, file := range files {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}
+
+	if options.Input != "" && options.Replay != "" {
+		return nil, nil, closeFiles, errors.New("Cannot use both an input and a replay file")
+	}
+
+	prompt, more := fim.StdioPrompt(stdin, stdout)
+
+	if options.Input != "" {
+		file, err := os.Open(options.Input)
+		if err != nil {
+			return nil, nil, closeFiles, err
+		}
+		files = append(files, file)
+
+		prompt, more = fim.InputPrompt(file, stdout)
+	}
+
+	if options.Replay != "" {
+		file, err := os.Open(options.Replay)
+		if err != nil {
+			return nil, nil, closeFiles, err
+		}
+		files = append(files, file)
+
+		prompt, more, err = fim.ReplayPrompt(file, stdout)
+		if err != nil {
+			return nil, nil, closeFiles, err
+		}
+	}
+
+	if options.Record != "" {
+		file, err := os.Create(options.Record)
+		if err != nil {
+			return nil, nil, closeFiles, err
+		}
+		files = append(files, file)
+
+		prompt = fim.RecordPrompt(prompt, file)
+	}
+
+	return prompt, more, closeFiles, nil
+}
+
+// Look up the comma-separated environment variables that the report is allowed to read.
+// Variables which are not set are read as an empty string.
+func allowEnvironment(names string, lookup func(name string) (string, bool)) map[string]string {
+	environment := make(map[string]string)
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		value, _ := lookup(name)
+		environment[name] = value
+	}
+
+	return environment
+}
+
+// Add the -e flag, which reads the report from the command line instead of a file.
+func addInlineFlag(flags *flag.FlagSet) {
+	flags.String("e", "", "Read the report from this source instead of a file")
+}
+
+// Load the report from the -e flag if it is set, or else from the file in the
+// first argument. Returns the arguments that come after the report.
+func loadSource(flags *flag.FlagSet, stdin io.Reader) (string, []string, error) {
+	inline := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "e" {
+			inline = true
+		}
+	})
+	if inline {
+		return flags.Lookup("e").Value.String(), flags.Args(), nil
+	}
+
+	if flags.NArg() == 0 {
+		return "", nil, errors.New("No report was given")
+	}
+
+	source, err := readSource(flags.Arg(0), stdin)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return source, flags.Args()[1:], nil
+}
+
+// Read the report from a file, or from stdin if the path is '-'.
+func readSource(path string, stdin io.Reader) (string, error) {
+	if path == "-" {
+		rawSource, err := io.ReadAll(stdin)
+		return string(rawSource), err
+	}
+
+	if stat, err := os.Stat(path); err != nil || !stat.Mode().IsRegular() {
+		return "", fmt.Errorf("Invalid file '%s'", path)
+	}
+
+	rawSource, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("An error has occured while trying to load file '%s'\n%s", path, err.Error())
+	}
+
+	return string(rawSource), nil
+}
+
+// Run the main paragraphs of the report, and return the exit code.
+func runReport(source string, options reportOptions) int {
+	program, err := fim.Compile(source, fim.CompileOptions{})
+	if err != nil {
+		return reportError(options.Stderr, err)
+	}
+
+	if options.Pretty {
+		fmt.Fprintf(options.Stdout, "┌─ fim (%s)\n", BuildVersion)
+		fmt.Fprintf(options.Stdout, "├─ Report Name: %s\n", program.Title())
+		fmt.Fprintf(options.Stdout, "└─ Report Author: %s\n", program.Author())
+	}
+
+	result, err := program.Run(context.Background(), fim.RunOptions{
+		Stdin:             options.Stdin,
+		Stdout:            options.Stdout,
+		Stderr:            options.Stderr,
+		Prompt:            options.Prompt,
+		MoreInput:         options.MoreInput,
+		DisableAssertions: options.DisableAssertions,
+		Sandbox:           options.Sandbox,
+		Environment:       options.Environment,
+		Arguments:         options.Arguments,
+	})
+	if err != nil {
+		return reportError(options.Stderr, err)
+	}
+
+	code, err := exitCode(result)
+	if err != nil {
+		return reportError(options.Stderr, err)
+	}
+
+	return code
+}
+
+// Print the error of a report, and return the exit code of its stage.
+// Errors without a stage are reported as runtime errors.
+func reportError(stderr io.Writer, err error) int {
+	stage := fim.STAGE_RUNTIME
+
+	var fimError *fim.Error
+	if errors.As(err, &fimError) {
+		stage = fimError.Stage
+	}
+
+	switch stage {
+	case fim.STAGE_PARSE:
+		fmt.Fprintln(stderr, "Spike noticed something unusual in your report...")
+		fmt.Fprintln(stderr, err)
+		return EXIT_PARSE_ERROR
+	case fim.STAGE_SETUP:
+		fmt.Fprintln(stderr, "Princess Celestia noticed something unusual in your report...")
+		fmt.Fprintln(stderr, err)
+		return EXIT_SETUP_ERROR
+	default:
+		fmt.Fprintln(stderr, "Princess Celestia caught something unusual in your report!")
+		fmt.Fprintln(stderr, err)
+		return EXIT_RUNTIME_ERROR
+	}
+}
+
+// Returns the exit code set by the report.
+//
+// A main paragraph which returns a number sets the exit code. If multiple
+// main paragraphs return a number, the last one is used.
+func exitCode(result *fim.Result) (int, error) {
+	code := EXIT_SUCCESS
+
+	for _, value := range result.ReturnValues {
+		if value == nil || value.GetType() != variable.NUMBER {
+			continue
+		}
+
+		number := value.GetValueNumber()
+		if number != math.Trunc(number) || number < 0 || number > 255 {
+			return EXIT_RUNTIME_ERROR, fmt.Errorf("Exit code must be a whole number from 0 to 255, got %s", value.GetValueString())
+		}
+		code = int(number)
+	}
+
+	return code, nil
+}
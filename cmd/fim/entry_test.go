@@ -0,0 +1,371 @@
+//go:build !js
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.jaezmien.com/Jaezmien/fim"
+	"git.jaezmien.com/Jaezmien/fim/spike"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"github.com/stretchr/testify/assert"
+)
+
+func runEntry(t *testing.T, source string, stdin string) (int, string, string) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	code := runReport(source, reportOptions{
+		Stdin:  strings.NewReader(stdin),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestEntry(t *testing.T) {
+	t.Run("should run a report", func(t *testing.T) {
+		source, err := readSource(filepath.Join("..", "..", "samples", "hello.fim"), nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		code, stdout, stderr := runEntry(t, source, "")
+		assert.Equal(t, EXIT_SUCCESS, code)
+		assert.Equal(t, "Hello World!\n", stdout)
+		assert.Empty(t, stderr)
+	})
+
+	t.Run("should read the report from stdin", func(t *testing.T) {
+		source, err := readSource("-", strings.NewReader("Dear Princess Celestia"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Dear Princess Celestia", source)
+	})
+
+	t.Run("should read the report from the command line", func(t *testing.T) {
+		flags := flag.NewFlagSet("run", flag.ContinueOnError)
+		addInlineFlag(flags)
+		if !assert.NoError(t, flags.Parse([]string{"-e", "Dear Princess Celestia", "Apple", "Jack"})) {
+			return
+		}
+
+		source, arguments, err := loadSource(flags, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Dear Princess Celestia", source)
+		assert.Equal(t, []string{"Apple", "Jack"}, arguments)
+	})
+
+	t.Run("should pass the arguments after the report", func(t *testing.T) {
+		flags := flag.NewFlagSet("run", flag.ContinueOnError)
+		addInlineFlag(flags)
+		if !assert.NoError(t, flags.Parse([]string{"-", "Apple", "-Jack"})) {
+			return
+		}
+
+		source, arguments, err := loadSource(flags, strings.NewReader("Dear Princess Celestia"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Dear Princess Celestia", source)
+		assert.Equal(t, []string{"Apple", "-Jack"}, arguments)
+
+		flags = flag.NewFlagSet("run", flag.ContinueOnError)
+		addInlineFlag(flags)
+		_, _, err = loadSource(flags, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("should check a report without running it", func(t *testing.T) {
+		source := `#!/usr/bin/env fim
+		Dear Princess Celestia: Checking!
+		Today I learned how to check!
+			I said "Unreachable"!
+		That's all about how to check.
+		Your faithful student, Twilight Sparkle.`
+
+		stderr := &bytes.Buffer{}
+		assert.Equal(t, EXIT_SUCCESS, checkReport(source, stderr))
+		assert.Empty(t, stderr.String())
+
+		assert.Equal(t, EXIT_PARSE_ERROR, checkReport("Dear Princess Celestia: Broken!", stderr))
+		assert.Contains(t, stderr.String(), "Spike noticed something unusual in your report...")
+	})
+
+	t.Run("should keep piped input between prompts", func(t *testing.T) {
+		source := `Dear Princess Celestia: Prompts!
+		Today I learned how to ask twice!
+			Did you know that Applejack is a word?
+			I asked Applejack: "".
+			I said Applejack!
+			I asked Applejack: "".
+			I said Applejack!
+		That's all about how to ask twice.
+		Your faithful student, Twilight Sparkle.`
+
+		code, stdout, _ := runEntry(t, source, "Apple\r\nJack")
+		assert.Equal(t, EXIT_SUCCESS, code)
+		assert.Equal(t, "Apple\nJack\n", stdout)
+	})
+
+	t.Run("should exit with a parse error", func(t *testing.T) {
+		code, stdout, stderr := runEntry(t, "Dear Princess Celestia: Broken!", "")
+		assert.Equal(t, EXIT_PARSE_ERROR, code)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "Spike noticed something unusual in your report...")
+	})
+
+	t.Run("should exit with a setup error", func(t *testing.T) {
+		source := `Dear Princess Celestia: Setup!
+		Did you know that Spike is the number 1?
+		Did you know that Spike is the number 2?
+		Today I learned how to set up!
+		That's all about how to set up.
+		Your faithful student, Twilight Sparkle.`
+
+		code, _, stderr := runEntry(t, source, "")
+		assert.Equal(t, EXIT_SETUP_ERROR, code)
+		assert.Contains(t, stderr, "Variable 'Spike' already exists.")
+	})
+
+	t.Run("should exit with a runtime error", func(t *testing.T) {
+		source := `Dear Princess Celestia: Runtime!
+		Today I learned how to fail!
+			I made sure that 1 is 2.
+		That's all about how to fail.
+		Your faithful student, Twilight Sparkle.`
+
+		code, _, stderr := runEntry(t, source, "")
+		assert.Equal(t, EXIT_RUNTIME_ERROR, code)
+		assert.Contains(t, stderr, "Assertion failed: 1 is 2")
+	})
+
+	t.Run("should exit with the number returned by the main paragraph", func(t *testing.T) {
+		source := `Dear Princess Celestia: Exit!
+		Today I learned how to finish to get a number!
+			I said "Goodbye"!
+			Then you get 3!
+		That's all about how to finish.
+		Your faithful student, Twilight Sparkle.`
+
+		code, stdout, stderr := runEntry(t, source, "")
+		assert.Equal(t, 3, code)
+		assert.Equal(t, "Goodbye\n", stdout)
+		assert.Empty(t, stderr)
+	})
+
+	t.Run("should exit with a runtime error on an invalid exit code", func(t *testing.T) {
+		source := `Dear Princess Celestia: Exit!
+		Today I learned how to finish to get a number!
+			Then you get 1.5!
+		That's all about how to finish.
+		Your faithful student, Twilight Sparkle.`
+
+		code, _, stderr := runEntry(t, source, "")
+		assert.Equal(t, EXIT_RUNTIME_ERROR, code)
+		assert.Contains(t, stderr, "Exit code must be a whole number from 0 to 255")
+	})
+
+	t.Run("should only allow the listed environment variables", func(t *testing.T) {
+		lookup := func(name string) (string, bool) {
+			if name == "HOME" {
+				return "/home/twilight", true
+			}
+			return "", false
+		}
+
+		environment := allowEnvironment(" HOME,,UNSET ", lookup)
+		assert.Equal(t, map[string]string{"HOME": "/home/twilight", "UNSET": ""}, environment)
+	})
+}
+
+func TestAST(t *testing.T) {
+	program, err := fim.Compile(`Dear Princess Celestia: Syntax!
+	Today I learned how to count!
+		I said 1 plus Spike!
+	That's all about how to count.
+	Your faithful student, Twilight Sparkle.`, fim.CompileOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("should print a tree", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, printAST(output, spike.Serialize(program.Report()), "tree")) {
+			return
+		}
+
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		if !assert.Len(t, lines, 8) {
+			return
+		}
+		assert.Regexp(t, `^ReportNode +0 +\d+  Author="Twilight Sparkle" Title="Syntax"$`, lines[1])
+		assert.Regexp(t, `^ +├─ Left: LiteralNode +\d+ +1  Value=\{Value="1" VariableType="NUMBER"\}$`, lines[6])
+		assert.Regexp(t, `^ +└─ Right: IdentifierNode +\d+ +5  Identifier="Spike"$`, lines[7])
+	})
+
+	t.Run("should print JSON", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, printAST(output, spike.Serialize(program.Report()), "json")) {
+			return
+		}
+
+		var report map[string]any
+		if !assert.NoError(t, json.Unmarshal(output.Bytes(), &report)) {
+			return
+		}
+		assert.Equal(t, "nodes.ReportNode", report["Type"])
+		assert.Equal(t, "Syntax", report["Title"])
+	})
+}
+
+func TestTokens(t *testing.T) {
+	source := "Dear Princess Celestia: Tokens!\nI said 1!"
+
+	t.Run("should print JSON", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, printTokens(output, source, twilight.Parse(source), "json")) {
+			return
+		}
+
+		expects := `{"version":1,"tokens":[` +
+			`{"type":"REPORT(HEADER)","value":"Dear Princess Celestia:","start":0,"length":23,"line":1,"column":1,"end_line":1,"end_column":24},` +
+			`{"type":"IDENTIFIER","value":"Tokens","start":24,"length":6,"line":1,"column":25,"end_line":1,"end_column":31},` +
+			`{"type":"PUNCTUATION","value":"!","start":30,"length":1,"line":1,"column":31,"end_line":1,"end_column":32},` +
+			`{"type":"PRINT(NEWLINE)","value":"I said","start":32,"length":6,"line":2,"column":1,"end_line":2,"end_column":7},` +
+			`{"type":"LITERAL(NUMBER)","value":"1","start":39,"length":1,"line":2,"column":8,"end_line":2,"end_column":9},` +
+			`{"type":"PUNCTUATION","value":"!","start":40,"length":1,"line":2,"column":9,"end_line":2,"end_column":10},` +
+			`{"type":"EOF","value":"","start":41,"length":0,"line":2,"column":10,"end_line":2,"end_column":10}]}` + "\n"
+		assert.Equal(t, expects, output.String())
+	})
+
+	t.Run("should print CSV with the trivia tokens", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, printTokens(output, source, twilight.ParseWithTrivia(source), "csv")) {
+			return
+		}
+
+		lines := strings.Split(output.String(), "\n")
+		assert.Equal(t, "type,value,start,length,line,column,end_line,end_column", lines[0])
+		assert.Equal(t, `WHITESPACE," ",23,1,1,24,1,25`, lines[2])
+		assert.Equal(t, "NEWLINE,\"\n\",31,1,1,32,2,1", lines[5]+"\n"+lines[6])
+	})
+}
+
+func TestPromptFiles(t *testing.T) {
+	source := `Dear Princess Celestia: Prompts!
+	Today I learned how to ask twice!
+		Did you know that Applejack is a word?
+		I asked Applejack: "First? ".
+		I said Applejack!
+		I asked Applejack: "Second? ".
+		I said Applejack!
+	That's all about how to ask twice.
+	Your faithful student, Twilight Sparkle.`
+
+	runWithPrompt := func(t *testing.T, options inputOptions, stdin string) (int, string, string) {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+
+		prompt, more, closeFiles, err := createPrompt(options, strings.NewReader(stdin), stdout)
+		if !assert.NoError(t, err) {
+			return -1, "", ""
+		}
+
+		code := runReport(source, reportOptions{
+			Stdout:    stdout,
+			Stderr:    stderr,
+			Prompt:    prompt,
+			MoreInput: more,
+		})
+		assert.NoError(t, closeFiles())
+
+		return code, stdout.String(), stderr.String()
+	}
+
+	t.Run("should answer with an input file", func(t *testing.T) {
+		input := filepath.Join(t.TempDir(), "input.txt")
+		if !assert.NoError(t, os.WriteFile(input, []byte("Apple\nJack\n"), 0644)) {
+			return
+		}
+
+		code, stdout, _ := runWithPrompt(t, inputOptions{Input: input}, "")
+		assert.Equal(t, EXIT_SUCCESS, code)
+		assert.Equal(t, "First? Apple\nSecond? Jack\n", stdout)
+	})
+
+	t.Run("should report running out of input at the prompt", func(t *testing.T) {
+		input := filepath.Join(t.TempDir(), "input.txt")
+		if !assert.NoError(t, os.WriteFile(input, []byte("Apple\n"), 0644)) {
+			return
+		}
+
+		code, _, stderr := runWithPrompt(t, inputOptions{Input: input}, "")
+		assert.Equal(t, EXIT_RUNTIME_ERROR, code)
+		assert.Contains(t, stderr, "Ran out of scripted input")
+		assert.Contains(t, stderr, `I asked Applejack: "Second? ".`)
+	})
+
+	t.Run("should replay a recording", func(t *testing.T) {
+		recording := filepath.Join(t.TempDir(), "recording.jsonl")
+
+		code, recorded, _ := runWithPrompt(t, inputOptions{Record: recording}, "Apple\nJack\n")
+		if !assert.Equal(t, EXIT_SUCCESS, code) {
+			return
+		}
+
+		code, replayed, _ := runWithPrompt(t, inputOptions{Replay: recording}, "")
+		assert.Equal(t, EXIT_SUCCESS, code)
+		assert.Equal(t, recorded, replayed)
+	})
+
+	t.Run("should not use both an input and a replay file", func(t *testing.T) {
+		_, _, closeFiles, err := createPrompt(inputOptions{Input: "input.txt", Replay: "recording.jsonl"}, nil, nil)
+		assert.Error(t, err)
+		assert.NoError(t, closeFiles())
+	})
+}
+
+// Builds the WASI command, and runs it if a WASI runtime is available.
+func TestWasip1Build(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping wasip1 build in short mode")
+	}
+
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	output := filepath.Join(t.TempDir(), "fim.wasm")
+
+	build := exec.Command(goBinary, "build", "-o", output, ".")
+	build.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if out, err := build.CombinedOutput(); !assert.NoError(t, err, string(out)) {
+		return
+	}
+
+	runtime, err := exec.LookPath("wasmtime")
+	if err != nil {
+		t.Log("wasmtime not found, skipping the wasip1 run")
+		return
+	}
+
+	run := exec.Command(runtime, "run", "--dir", ".", output, filepath.Join("..", "..", "samples", "hello.fim"))
+	out, err := run.Output()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello World!\n", string(out))
+}
@@ -0,0 +1,86 @@
+//go:build !js
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+)
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: fim <command> [flags] [arguments...]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintln(w, "  run        Run a report")
+	fmt.Fprintln(w, "  tokens     Print the tokens of a report")
+	fmt.Fprintln(w, "  ast        Print the syntax tree of a report")
+	fmt.Fprintln(w, "  check      Check a report for errors without running it")
+	fmt.Fprintln(w, "  fmt        Format reports")
+	fmt.Fprintln(w, "  lint       Check reports for suspicious code")
+	fmt.Fprintln(w, "  test       Run the tests of reports")
+	fmt.Fprintln(w, "  doc        Print the documentation of a report")
+	fmt.Fprintln(w, "  highlight  Print a report with syntax highlighting")
+	fmt.Fprintln(w, "  version    Show the current version")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'fim <command> -h' to see the flags of a command.")
+	fmt.Fprintln(w, "A report can also be run with 'fim <file> [arguments...]', so that it can be used as an executable script.")
+}
+
+// fim version
+func versionCommand(args []string) int {
+	flags := flag.NewFlagSet("version", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: fim version")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	fmt.Println(BuildVersion)
+
+	return EXIT_SUCCESS
+}
+
+// fim <command> [flags] [arguments...]
+func main() {
+	if len(os.Args) < 2 {
+		// WASI runtimes are usually given the report through stdin, as they cannot always access files
+		if runtime.GOOS == "wasip1" {
+			os.Exit(runCommand([]string{"-"}))
+		}
+
+		printUsage(os.Stderr)
+		os.Exit(EXIT_USAGE_ERROR)
+	}
+
+	args := os.Args[2:]
+	switch os.Args[1] {
+	case "run":
+		os.Exit(runCommand(args))
+	case "tokens":
+		os.Exit(tokensCommand(args))
+	case "ast":
+		os.Exit(astCommand(args))
+	case "check":
+		os.Exit(checkCommand(args))
+	case "fmt":
+		os.Exit(formatCommand(args))
+	case "lint":
+		os.Exit(lintCommand(args))
+	case "test":
+		os.Exit(testCommand(args))
+	case "doc":
+		os.Exit(docCommand(args))
+	case "highlight":
+		os.Exit(highlightCommand(args))
+	case "version":
+		os.Exit(versionCommand(args))
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout)
+	default:
+		// Executable scripts are run as 'fim <file> [arguments...]'
+		os.Exit(runCommand(os.Args[1:]))
+	}
+}
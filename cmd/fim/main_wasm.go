@@ -0,0 +1,440 @@
+//go:build js
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall/js"
+	"time"
+
+	"git.jaezmien.com/Jaezmien/fim"
+	"git.jaezmien.com/Jaezmien/fim/applejack"
+	"git.jaezmien.com/Jaezmien/fim/spike"
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	lunaErrors "git.jaezmien.com/Jaezmien/fim/luna/errors"
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+type CallbackWriter struct {
+	Callback *js.Value
+}
+
+func NewCallbackWriter(callback *js.Value) (*CallbackWriter, error) {
+	if callback.Type() != js.TypeFunction {
+		return nil, errors.New("Expected callback function to be a JS function")
+	}
+
+	return &CallbackWriter{
+		Callback: callback,
+	}, nil
+}
+func (w *CallbackWriter) Write(p []byte) (n int, err error) {
+	w.Callback.Invoke(string(p))
+	return len(p), nil
+}
+
+func Exists(v js.Value) bool {
+	return !v.IsNull() && !v.IsUndefined()
+}
+
+// Checks if the value is a JS Promise, or any other thenable.
+func IsThenable(v js.Value) bool {
+	return v.Type() == js.TypeObject && v.Get("then").Type() == js.TypeFunction
+}
+
+// Wait for a thenable to settle, or until the context is done.
+// Any other value is returned as is.
+//
+// This blocks the current goroutine, so it must not be called from the
+// goroutine that is handling a JS callback.
+func Await(ctx context.Context, v js.Value) (js.Value, error) {
+	if !IsThenable(v) {
+		return v, nil
+	}
+
+	type result struct {
+		value js.Value
+		err   error
+	}
+	settled := make(chan result, 1)
+
+	onFulfilled := js.FuncOf(func(this js.Value, args []js.Value) any {
+		value := js.Undefined()
+		if len(args) > 0 {
+			value = args[0]
+		}
+		settled <- result{value: value}
+		return nil
+	})
+	defer onFulfilled.Release()
+
+	onRejected := js.FuncOf(func(this js.Value, args []js.Value) any {
+		reason := "Promise was rejected"
+		if len(args) > 0 && Exists(args[0]) {
+			reason = args[0].Call("toString").String()
+		}
+		settled <- result{err: errors.New(reason)}
+		return nil
+	})
+	defer onRejected.Release()
+
+	v.Call("then", onFulfilled, onRejected)
+
+	select {
+	case r := <-settled:
+		return r.value, r.err
+	case <-ctx.Done():
+		return js.Undefined(), ctx.Err()
+	}
+}
+
+// Create a structured error object for JS.
+//
+// The stage is either "arguments", "parse", "setup", or "runtime".
+func NewErrorObject(err error) map[string]any {
+	object := map[string]any{
+		"stage":   "arguments",
+		"message": err.Error(),
+		"details": err.Error(),
+	}
+
+	var fimError *fim.Error
+	if errors.As(err, &fimError) {
+		object["stage"] = string(fimError.Stage)
+
+		if fimError.HasOrigin {
+			object["start"] = fimError.Index
+			object["line"] = fimError.Line
+			object["column"] = fimError.Column
+		}
+	}
+
+	var parseError lunaErrors.ParseError
+	if errors.As(err, &parseError) {
+		object["message"] = parseError.Message
+	}
+
+	return object
+}
+
+// Create a JS token object.
+func NewTokenObject(t *token.Token, file *luna.SourceFile) map[string]any {
+	tokenRange := t.Range(file)
+
+	return map[string]any{
+		"type":       t.Type.String(),
+		"value":      t.Value,
+		"start":      t.Start,
+		"length":     t.Length,
+		"line":       tokenRange.Start.Line,
+		"column":     tokenRange.Start.Column,
+		"end_line":   tokenRange.End.Line,
+		"end_column": tokenRange.End.Column,
+	}
+}
+
+// Create a JS diagnostic object from a linter issue.
+func NewIssueObject(issue applejack.Issue) map[string]any {
+	return map[string]any{
+		"severity":   "warning",
+		"stage":      "lint",
+		"rule":       string(issue.Rule),
+		"message":    issue.Message,
+		"details":    issue.String(),
+		"start":      issue.Start,
+		"length":     issue.Length,
+		"line":       issue.Line,
+		"column":     issue.Column,
+		"end_line":   issue.EndLine,
+		"end_column": issue.EndColumn,
+	}
+}
+
+// Create a new JS Promise, with its executor running in a new goroutine.
+//
+// The executor's return value resolves the promise. Cancelling the returned
+// promise through its 'cancel' method cancels the executor's context.
+// Once the promise is settled, its 'cancel' method does nothing.
+func NewPromise(executor func(ctx context.Context) any) js.Value {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The promise is only known once the executor has already started
+	promises := make(chan js.Value, 1)
+
+	var handler, cancelHandler js.Func
+	handler = js.FuncOf(func(this js.Value, args []js.Value) any {
+		resolve := args[0]
+
+		go func() {
+			defer handler.Release()
+			defer cancelHandler.Release()
+			defer cancel()
+
+			resolve.Invoke(executor(ctx))
+
+			(<-promises).Set("cancel", js.Global().Get("Function").New())
+		}()
+
+		return nil
+	})
+	cancelHandler = js.FuncOf(func(this js.Value, args []js.Value) any {
+		cancel()
+		return nil
+	})
+
+	promise := js.Global().Get("Promise").New(handler)
+	promise.Set("cancel", cancelHandler)
+	promises <- promise
+
+	return promise
+}
+
+// Create a statement hook that periodically lets the JS event loop run, so that
+// output and cancellation can be handled while a report is busy.
+func yieldHook() func(statement node.DynamicNode) error {
+	const YieldInterval = 50 * time.Millisecond
+
+	lastYield := time.Now()
+	return func(statement node.DynamicNode) error {
+		if time.Since(lastYield) < YieldInterval {
+			return nil
+		}
+
+		time.Sleep(time.Millisecond)
+		lastYield = time.Now()
+		return nil
+	}
+}
+
+func main() {
+	c := make(chan struct{}, 0)
+
+	// fim(source: string) => [tokens: string[], error: string | null]
+	js.Global().Set("fim", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 {
+			return []any{nil, "Expected only one argument"}
+		}
+		if args[0].Type() != js.TypeString {
+			return []any{nil, "Expected argument 0 to be type of string"}
+		}
+
+		source := args[0]
+
+		tokens := twilight.Parse(source.String())
+
+		result := make([]any, 0)
+		for _, token := range tokens {
+			if len(token.Value) > 0 {
+				result = append(result, token.Value)
+			}
+		}
+
+		return []any{result, nil}
+	}))
+
+	// type FimToken = { type: string, value: string, start: number, length: number, line: number, column: number }
+	//
+	// fim_tokens(source: string, options?: { trivia?: boolean }) => [tokens: FimToken[], error: string | null]
+	js.Global().Set("fim_tokens", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) < 1 || args[0].Type() != js.TypeString {
+			return []any{nil, "Expected argument 0 to be type of string"}
+		}
+		source := args[0].String()
+
+		trivia := false
+		if len(args) >= 2 && args[1].Type() == js.TypeObject {
+			trivia = args[1].Get("trivia").Truthy()
+		}
+
+		var tokens []*token.Token
+		if trivia {
+			tokens = twilight.ParseWithTrivia(source)
+		} else {
+			tokens = twilight.Parse(source)
+		}
+
+		file := luna.NewSourceFile(source)
+		result := make([]any, 0, len(tokens))
+		for _, t := range tokens {
+			result = append(result, NewTokenObject(t, file))
+		}
+
+		return []any{result, nil}
+	}))
+
+	// fim_ast(source: string) => [ast: object | null, error: FimError | null]
+	js.Global().Set("fim_ast", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) < 1 || args[0].Type() != js.TypeString {
+			return []any{nil, NewErrorObject(errors.New("Expected argument 0 to be type of string"))}
+		}
+		source := args[0].String()
+
+		program, err := fim.Compile(source, fim.CompileOptions{})
+		if err != nil {
+			return []any{nil, NewErrorObject(err)}
+		}
+
+		return []any{spike.Serialize(program.Report()), nil}
+	}))
+
+	// type FimDiagnostic = FimError & { severity: "error" | "warning", rule?: string, length?: number }
+	//
+	// fim_check(source: string) => [diagnostics: FimDiagnostic[], error: string | null]
+	js.Global().Set("fim_check", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) < 1 || args[0].Type() != js.TypeString {
+			return []any{nil, "Expected argument 0 to be type of string"}
+		}
+		source := args[0].String()
+
+		diagnostics := make([]any, 0)
+
+		if _, err := fim.Compile(source, fim.CompileOptions{}); err != nil {
+			diagnostic := NewErrorObject(err)
+			diagnostic["severity"] = "error"
+			diagnostics = append(diagnostics, diagnostic)
+
+			return []any{diagnostics, nil}
+		}
+
+		issues, err := applejack.Lint(source, applejack.DefaultConfig())
+		if err != nil {
+			return []any{nil, err.Error()}
+		}
+
+		for _, issue := range issues {
+			diagnostics = append(diagnostics, NewIssueObject(issue))
+		}
+
+		return []any{diagnostics, nil}
+	}))
+
+	// type FimError = { stage: string, message: string, details: string, start?: number, line?: number, column?: number }
+	// type FimResult = { status: "completed" | "cancelled" | "error", error?: FimError }
+	//
+	// fim_exec(
+	//   source: string,
+	//   output?: (data: string) => void,
+	//   prompt?: (prompt: string) => string | null | Promise<string | null>,
+	//   error?: (info: string) => void,
+	//   more?: () => boolean | Promise<boolean>
+	// ) => Promise<FimResult> & { cancel: () => void }
+	//
+	// The prompt returns null at the end of the input. The more callback tells
+	// whether the prompt has more input to read, for 'there is more to read'.
+	// Without it, there is more to read until the prompt returns null.
+	js.Global().Set("fim_exec", js.FuncOf(func(this js.Value, args []js.Value) any {
+		args = append([]js.Value{}, args...)
+
+		return NewPromise(func(ctx context.Context) any {
+			failed := func(err error) map[string]any {
+				return map[string]any{
+					"status": "error",
+					"error":  NewErrorObject(err),
+				}
+			}
+
+			console := js.Global().Get("console")
+			if !Exists(console) {
+				return failed(errors.New("Could not get console"))
+			}
+
+			promptCallback := js.Global().Get("prompt")
+
+			console_log := console.Get("log")
+			outputCallback, err := NewCallbackWriter(&console_log)
+			if err != nil {
+				return failed(errors.New("Could not get console.log"))
+			}
+
+			console_error := console.Get("error")
+			errorCallback, err := NewCallbackWriter(&console_error)
+			if err != nil {
+				return failed(errors.New("Could not get console.error"))
+			}
+
+			if len(args) < 1 {
+				return failed(errors.New("Expected at least one argument"))
+			}
+
+			if args[0].Type() != js.TypeString {
+				return failed(errors.New("Expected argument 0 to be type of string"))
+			}
+			source := args[0].String()
+
+			if len(args) >= 2 && args[1].Type() == js.TypeFunction {
+				outputCallback, err = NewCallbackWriter(&args[1])
+				if err != nil {
+					return failed(err)
+				}
+			}
+
+			if len(args) >= 3 && args[2].Type() == js.TypeFunction {
+				promptCallback = args[2]
+			}
+
+			if len(args) >= 4 && args[3].Type() == js.TypeFunction {
+				errorCallback, err = NewCallbackWriter(&args[3])
+				if err != nil {
+					return failed(err)
+				}
+			}
+
+			var moreInput fim.MoreInput
+			if len(args) >= 5 && args[4].Type() == js.TypeFunction {
+				moreCallback := args[4]
+				moreInput = func() bool {
+					result, err := Await(ctx, moreCallback.Invoke())
+					return err == nil && result.Type() == js.TypeBoolean && result.Bool()
+				}
+			}
+
+			program, err := fim.Compile(source, fim.CompileOptions{})
+			if err != nil {
+				fmt.Fprintln(errorCallback, err)
+				return failed(err)
+			}
+
+			_, err = program.Run(ctx, fim.RunOptions{
+				Stdout: outputCallback,
+				Stderr: errorCallback,
+				Prompt: func(prompt string) (string, error) {
+					if promptCallback.Type() != js.TypeFunction {
+						return "", errors.New("No prompt callback was given")
+					}
+
+					result, err := Await(ctx, promptCallback.Invoke(prompt))
+					if err != nil {
+						return "", err
+					}
+					if result.Type() == js.TypeNull {
+						return "", io.EOF
+					}
+					if result.Type() != js.TypeString {
+						return "", fmt.Errorf("Expected prompt callback to return a string, got %s", result.Type())
+					}
+					return result.String(), nil
+				},
+				MoreInput:   moreInput,
+				OnStatement: yieldHook(),
+			})
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return map[string]any{"status": "cancelled"}
+				}
+
+				fmt.Fprintln(errorCallback, err)
+				return failed(err)
+			}
+
+			return map[string]any{"status": "completed"}
+		})
+	}))
+
+	<-c
+}
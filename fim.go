@@ -0,0 +1,366 @@
+// Package fim compiles and runs FiM++ reports.
+//
+// It wraps the three stages of the interpreter: twilight (tokenizer),
+// spike (AST builder), and celestia (interpreter).
+package fim
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"git.jaezmien.com/Jaezmien/fim/celestia"
+	"git.jaezmien.com/Jaezmien/fim/spike"
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+
+	lunaErrors "git.jaezmien.com/Jaezmien/fim/luna/errors"
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// The stage where an error occured
+type Stage string
+
+const (
+	STAGE_PARSE   Stage = "parse"
+	STAGE_SETUP   Stage = "setup"
+	STAGE_RUNTIME Stage = "runtime"
+)
+
+var ErrStatementLimit = errors.New("Report has exceeded the statement limit")
+
+// An Error is an error raised while compiling or running a report.
+type Error struct {
+	Stage Stage
+	Err   error
+
+	// Whether the error has a position in the source
+	HasOrigin bool
+	// Character index of the error
+	Index int
+	// 1-based line number of the error
+	Line int
+	// 1-based column number of the error
+	Column int
+}
+
+func newError(stage Stage, err error, file *luna.SourceFile) *Error {
+	e := &Error{
+		Stage: stage,
+		Err:   err,
+	}
+
+	var parseError lunaErrors.ParseError
+	if errors.As(err, &parseError) {
+		e.HasOrigin = true
+		e.Index = parseError.Index
+
+		position := file.Position(parseError.Index)
+		e.Line, e.Column = position.Line, position.Column
+	}
+
+	return e
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Options used while compiling a report.
+// There are currently no compile options.
+type CompileOptions struct {
+}
+
+// A Program is a compiled report.
+//
+// The compiled report is never modified while running, so a Program
+// is safe to run from multiple goroutines at once.
+type Program struct {
+	source string
+	file   *luna.SourceFile
+	report *nodes.ReportNode
+}
+
+// Compile the report's source code.
+//
+// If the report is invalid, the error will be an *Error.
+func Compile(source string, options CompileOptions) (*Program, error) {
+	file := luna.NewSourceFile(source)
+
+	report, err := spike.CreateReport(twilight.Parse(source), source)
+	if err != nil {
+		return nil, newError(STAGE_PARSE, err, file)
+	}
+
+	return &Program{
+		source: source,
+		file:   file,
+		report: report,
+	}, nil
+}
+
+// Returns the report's source code.
+func (p *Program) Source() string {
+	return p.source
+}
+
+// Returns the compiled report.
+func (p *Program) Report() *nodes.ReportNode {
+	return p.report
+}
+
+// Return the report's title
+func (p *Program) Title() string {
+	return p.report.Title
+}
+
+// Return the report's author
+func (p *Program) Author() string {
+	return p.report.Author
+}
+
+// Set up the report without running it.
+//
+// This checks for errors that are only found once the report is set up,
+// such as duplicate paragraphs or global variables. The error will be an *Error.
+func (p *Program) Check() error {
+	if _, err := celestia.NewInterpreter(p.report, p.source); err != nil {
+		return newError(STAGE_SETUP, err, p.file)
+	}
+
+	return nil
+}
+
+// Options used while running a program.
+type RunOptions struct {
+	// Where the prompts are read from, if Prompt is not set
+	Stdin io.Reader
+	// Where the output is written to. If nil, the output is kept in the Result
+	Stdout io.Writer
+	// Where the interpreter errors are written to
+	Stderr io.Writer
+
+	// Answers the prompts of the report. Returning ErrSuspend suspends the run at the prompt
+	Prompt func(prompt string) (string, error)
+	// Reports whether Prompt has more input to read. If nil, there is more to read
+	// until Prompt returns io.EOF
+	MoreInput func() bool
+
+	// Name of the paragraph to run. If empty, every main paragraph is run
+	Entry string
+
+	// Skip every assertion statement
+	DisableAssertions bool
+
+	// Directory that the file statements are confined to. If empty, file access is disabled
+	Sandbox string
+	// Environment variables that the report is allowed to read, and their values
+	Environment map[string]string
+
+	// Values of the predefined Arguments array
+	Arguments []string
+
+	// Maximum amount of statements to execute, including each loop iteration, or 0 for no limit
+	MaxStatements int
+	// Maximum duration of the run, or 0 for no limit
+	Timeout time.Duration
+
+	// Called before every statement is executed. Returning an error stops execution,
+	// and returning ErrSuspend suspends the run before the statement
+	OnStatement func(statement node.DynamicNode) error
+}
+
+// The Result of running a program.
+type Result struct {
+	// The output of the report, if RunOptions.Stdout is not set
+	Output string
+	// The values returned by each paragraph that was run, or nil if it returned nothing
+	ReturnValues []*variable.DynamicVariable
+	// The amount of statements that were executed, including each loop iteration
+	Statements int
+
+	// The state of the report if it was suspended
+	Snapshot *Snapshot
+
+	Error *Error
+}
+
+// Run the program.
+//
+// Every run creates a fresh interpreter which holds the globals, scopes,
+// and I/O of that run, so no state is shared between runs.
+// If the run fails, the error is both returned and kept in the Result.
+//
+// If the run is suspended with ErrSuspend, no error is returned and the
+// Result holds a Snapshot that can be passed to Resume.
+func (p *Program) Run(ctx context.Context, options RunOptions) (*Result, error) {
+	return p.run(ctx, options, nil)
+}
+
+func (p *Program) run(ctx context.Context, options RunOptions, resume *Snapshot) (*Result, error) {
+	result := &Result{
+		ReturnValues: make([]*variable.DynamicVariable, 0),
+	}
+	fail := func(stage Stage, err error) (*Result, error) {
+		result.Error = newError(stage, err, p.file)
+		return result, result.Error
+	}
+
+	if resume != nil {
+		if err := resume.validate(p); err != nil {
+			return fail(STAGE_SETUP, err)
+		}
+		options.Entry = resume.Entry
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	output := &bytes.Buffer{}
+	stdout := options.Stdout
+	if stdout == nil {
+		stdout = output
+		defer func() { result.Output = output.String() }()
+	}
+	stderr := options.Stderr
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	stdin := options.Stdin
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+
+	interpreter, err := celestia.NewInterpreter(p.report, p.source)
+	if err != nil {
+		return fail(STAGE_SETUP, err)
+	}
+
+	interpreter.Context = ctx
+	interpreter.Writer = stdout
+	interpreter.ErrorWriter = stderr
+	interpreter.DisableAssertions = options.DisableAssertions
+	interpreter.Sandbox = options.Sandbox
+	interpreter.Environment = options.Environment
+	interpreter.SetArguments(options.Arguments)
+
+	if resume != nil {
+		interpreter.Variables.Restore(resume.Variables)
+		// The statement the run was suspended at is counted again once it is run
+		result.Statements = resume.Statements - 1
+	}
+
+	var current node.DynamicNode
+	paragraphIndex := 0
+	suspend := func() error {
+		execution, err := interpreter.State()
+		if err != nil {
+			return err
+		}
+
+		result.Snapshot = &Snapshot{
+			Version:    SnapshotVersion,
+			SourceHash: hashSource(p.source),
+			Entry:      options.Entry,
+			Paragraph:  paragraphIndex,
+			Statements: result.Statements,
+			Variables:  interpreter.Variables.Snapshot(),
+			Execution:  execution,
+		}
+		if current != nil {
+			result.Snapshot.Position = p.file.Position(current.ToNode().Start)
+		}
+		return nil
+	}
+
+	prompt, more := options.Prompt, options.MoreInput
+	if prompt == nil {
+		prompt, more = StdioPrompt(stdin, stdout)
+	}
+	interpreter.MoreInput = more
+	interpreter.Prompt = func(text string) (string, error) {
+		response, err := prompt(text)
+		if errors.Is(err, ErrSuspend) {
+			if err := suspend(); err != nil {
+				return "", err
+			}
+		}
+		return response, err
+	}
+
+	interpreter.StatementHook = func(statement node.DynamicNode) error {
+		current = statement
+
+		result.Statements++
+		if options.MaxStatements > 0 && result.Statements > options.MaxStatements {
+			return ErrStatementLimit
+		}
+
+		if options.OnStatement != nil {
+			err := options.OnStatement(statement)
+			if errors.Is(err, ErrSuspend) {
+				if err := suspend(); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+		return nil
+	}
+
+	paragraphs := make([]*celestia.Paragraph, 0)
+	for _, paragraph := range interpreter.Paragraphs {
+		if options.Entry == "" && paragraph.Main || options.Entry != "" && paragraph.Name == options.Entry {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	if options.Entry != "" && len(paragraphs) == 0 {
+		return fail(STAGE_SETUP, fmt.Errorf("Paragraph '%s' not found", options.Entry))
+	}
+
+	if resume != nil && resume.Paragraph >= len(paragraphs) {
+		return fail(STAGE_SETUP, fmt.Errorf("%w: paragraph %d does not exist", ErrSnapshotMismatch, resume.Paragraph))
+	}
+
+	for idx, paragraph := range paragraphs {
+		paragraphIndex = idx
+
+		var value *variable.DynamicVariable
+		switch {
+		case resume != nil && idx < resume.Paragraph:
+			continue
+		case resume != nil && idx == resume.Paragraph:
+			value, err = paragraph.Resume(resume.Execution)
+		default:
+			value, err = paragraph.Execute()
+		}
+
+		if errors.Is(err, ErrSuspend) && result.Snapshot != nil {
+			return result, nil
+		}
+		if errors.Is(err, celestia.ErrInvalidFrames) {
+			return fail(STAGE_SETUP, fmt.Errorf("%w: %w", ErrSnapshotMismatch, err))
+		}
+		if err != nil {
+			return fail(STAGE_RUNTIME, err)
+		}
+		result.ReturnValues = append(result.ReturnValues, value)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,226 @@
+package fim
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	t.Run("should compile a report", func(t *testing.T) {
+		source := `Dear Princess Celestia: Compiling!
+		Today I learned how to compile!
+		That's all about how to compile.
+		Your faithful student, Twilight Sparkle.`
+
+		program, err := Compile(source, CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Compiling", program.Title())
+		assert.Equal(t, "Twilight Sparkle", program.Author())
+	})
+
+	t.Run("should return a positioned parse error", func(t *testing.T) {
+		source := "Dear Princess Celestia: Compiling!\nToday"
+
+		_, err := Compile(source, CompileOptions{})
+
+		var fimError *Error
+		if !assert.ErrorAs(t, err, &fimError) {
+			return
+		}
+		assert.Equal(t, STAGE_PARSE, fimError.Stage)
+		assert.True(t, fimError.HasOrigin)
+		assert.Equal(t, 2, fimError.Line)
+		assert.Equal(t, 1, fimError.Column)
+	})
+
+	t.Run("should ignore a shebang line", func(t *testing.T) {
+		source := "#!/usr/bin/env fim\nDear Princess Celestia: Compiling!\nYour faithful student, Twilight Sparkle."
+
+		program, err := Compile(source, CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Compiling", program.Title())
+	})
+
+	t.Run("should check the report without running it", func(t *testing.T) {
+		source := `Dear Princess Celestia: Checking!
+		Did you know that Spike is the number 1?
+		Did you know that Spike is the number 2?
+		Today I learned how to check!
+			I said "Unreachable"!
+		That's all about how to check.
+		Your faithful student, Twilight Sparkle.`
+
+		program, err := Compile(source, CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var fimError *Error
+		if !assert.ErrorAs(t, program.Check(), &fimError) {
+			return
+		}
+		assert.Equal(t, STAGE_SETUP, fimError.Stage)
+		assert.Contains(t, fimError.Error(), "Variable 'Spike' already exists.")
+	})
+}
+
+func TestRun(t *testing.T) {
+	source := `Dear Princess Celestia: Running!
+	I learned how to count to get a number!
+		Then you get 3!
+	That's all about how to count.
+	Today I learned how to run!
+		Did you know that Applejack is a word?
+		I asked Applejack: "Name? ".
+		I said "Hello " plus Applejack!
+	That's all about how to run.
+	I learned how to loop forever!
+		As long as true,
+			I said "Loop"!
+		That's what I did.
+	That's all about how to loop forever.
+	Your faithful student, Twilight Sparkle.`
+
+	program, err := Compile(source, CompileOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("should read prompts from stdin", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{
+			Stdin: strings.NewReader("Rarity\n"),
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Name? Hello Rarity\n", result.Output)
+		assert.Equal(t, 3, result.Statements)
+	})
+
+	t.Run("should write to stdout", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		result, err := program.Run(context.Background(), RunOptions{
+			Stdout: stdout,
+			Prompt: func(prompt string) (string, error) { return "Spike", nil },
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Empty(t, result.Output)
+		assert.Equal(t, "Hello Spike\n", stdout.String())
+	})
+
+	t.Run("should run an entry paragraph", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{Entry: "how to count"})
+		if !assert.NoError(t, err) || !assert.Len(t, result.ReturnValues, 1) {
+			return
+		}
+		assert.Equal(t, float64(3), result.ReturnValues[0].GetValueNumber())
+	})
+
+	t.Run("should error on an unknown entry paragraph", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{Entry: "how to fly"})
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Equal(t, STAGE_SETUP, result.Error.Stage)
+	})
+
+	t.Run("should stop at the statement limit", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{
+			Entry:         "how to loop forever",
+			MaxStatements: 10,
+		})
+		assert.ErrorIs(t, err, ErrStatementLimit)
+		assert.Equal(t, STAGE_RUNTIME, result.Error.Stage)
+		assert.Equal(t, strings.Repeat("Loop\n", 4), result.Output)
+	})
+
+	t.Run("should stop at the timeout", func(t *testing.T) {
+		_, err := program.Run(context.Background(), RunOptions{
+			Entry:   "how to loop forever",
+			Timeout: 10 * time.Millisecond,
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("should call the statement hook", func(t *testing.T) {
+		stop := errors.New("stop")
+
+		_, err := program.Run(context.Background(), RunOptions{
+			Entry: "how to loop forever",
+			OnStatement: func(statement node.DynamicNode) error {
+				return stop
+			},
+		})
+		assert.ErrorIs(t, err, stop)
+	})
+}
+
+func TestConcurrentRuns(t *testing.T) {
+	source := `Dear Princess Celestia: Concurrency!
+	Did you know that Counter is the number 0?
+	Did you know that Names has many words?
+	I learned how to increment using the number Value to get a number!
+		Value got one more.
+		Then you get Value!
+	That's all about how to increment.
+	Today I learned how to run concurrently!
+		Did you know that Applejack is a word?
+		I asked Applejack: "".
+		1 of Names is Applejack.
+		For every number Index from 1 to 50,
+			Counter becomes how to increment using Counter.
+		That's what I did.
+		I said how to increment using 1!
+		Did you know that Name is the word 1 of Names?
+		I said Name plus " " plus Counter!
+	That's all about how to run concurrently.
+	Your faithful student, Twilight Sparkle.`
+
+	program, err := Compile(source, CompileOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	names := []string{"Applejack", "Rarity", "Fluttershy", "Rainbow Dash", "Pinkie Pie", "Twilight Sparkle"}
+
+	results := make(chan string, len(names)*4)
+	errs := make(chan error, len(names)*4)
+
+	for run := range len(names) * 4 {
+		name := names[run%len(names)]
+
+		go func() {
+			result, err := program.Run(context.Background(), RunOptions{
+				Prompt: func(prompt string) (string, error) { return name, nil },
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			results <- name + ":" + result.Output
+		}()
+	}
+
+	for range len(names) * 4 {
+		select {
+		case err := <-errs:
+			assert.NoError(t, err)
+		case output := <-results:
+			name, output, _ := strings.Cut(output, ":")
+			assert.Equal(t, "2\n"+name+" 50\n", output)
+		}
+	}
+}
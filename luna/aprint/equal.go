@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 type PrintAlign int
@@ -54,7 +55,7 @@ func (p *AlignedPrint) Add(content ...string) error {
 	}
 
 	for idx, value := range content {
-		length := len(value)
+		length := utf8.RuneCountInString(value)
 		if length >= p.maximumLines[idx] {
 			p.maximumLines[idx] = length
 		}
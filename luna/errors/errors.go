@@ -10,6 +10,8 @@ package errors
 import (
 	"fmt"
 	"strings"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
 )
 
 type FiMError struct {
@@ -31,20 +33,22 @@ type ErrorOrigin struct {
 	Line int
 	// 1-based column number of the error
 	Column int
+	// Character index of the error
+	Index int
 
 	lineContent string
 }
 
 // Create an ErrorOrigin based on a character index.
-func GetErrorOrigin(source string, index int) ErrorOrigin {
-	content := source[0:min(index+1, len(source))]
-	lines := strings.Split(content, "\n")
+func GetErrorOrigin(file *luna.SourceFile, index int) ErrorOrigin {
+	position := file.Position(index)
 
 	return ErrorOrigin{
-		Line:   len(lines) + 1,
-		Column: len(lines[len(lines)-1]) + 1,
+		Line:   position.Line,
+		Column: position.Column,
+		Index:  index,
 
-		lineContent: strings.ReplaceAll(strings.Split(source, "\n")[len(lines)-1], "\t", " "),
+		lineContent: strings.ReplaceAll(file.Line(position.Line), "\t", " "),
 	}
 }
 
@@ -66,14 +70,14 @@ func (e ParseError) GetErrorLine() string {
 
 	trimmedContent := strings.TrimLeft(e.lineContent, "\t ")
 	sb.WriteString(fmt.Sprintf("%s\n", trimmedContent))
-	sb.WriteString(fmt.Sprintf("%s^", strings.Repeat(" ", e.Column-1-(len(e.lineContent)-len(trimmedContent)))))
+	sb.WriteString(fmt.Sprintf("%s^", strings.Repeat(" ", max(0, e.Column-1-(len(e.lineContent)-len(trimmedContent))))))
 
 	return sb.String()
 }
 
-func NewParseError(msg string, source string, index int) ParseError {
+func NewParseError(msg string, file *luna.SourceFile, index int) ParseError {
 	return ParseError{
 		NewFiMError(msg),
-		GetErrorOrigin(source, index),
+		GetErrorOrigin(file, index),
 	}
 }
@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"testing"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	source := "Dear Princess Celestia: Errors!\n\tI said Spike!\n"
+
+	t.Run("should point at the origin of the error", func(t *testing.T) {
+		err := NewParseError("Unknown identifier", luna.NewSourceFile(source), 40)
+
+		assert.Equal(t, 2, err.Line)
+		assert.Equal(t, 9, err.Column)
+		assert.Equal(t, 40, err.Index)
+		assert.Equal(t, "[line 2:9] Unknown identifier\nI said Spike!\n       ^", err.Error())
+	})
+
+	t.Run("should point at the start of a line", func(t *testing.T) {
+		err := NewParseError("Unexpected statement", luna.NewSourceFile(source), 33)
+
+		assert.Equal(t, 2, err.Line)
+		assert.Equal(t, 2, err.Column)
+		assert.Equal(t, "[line 2:2] Unexpected statement\nI said Spike!\n^", err.Error())
+	})
+}
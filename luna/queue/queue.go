@@ -3,6 +3,9 @@ package queue
 // The Queue represents a Queue data structure
 type Queue[T any] struct {
 	front *QueueItem[T]
+	back  *QueueItem[T]
+
+	length int
 }
 
 // The QueueItem is a wrapper to allow double-linked list
@@ -24,15 +27,11 @@ func (q *Queue[T]) First() *QueueItem[T] {
 
 // Get the last item inserted into the queue
 func (q *Queue[T]) Last() *QueueItem[T] {
-	if q.front == nil {
+	if q.back == nil {
 		return nil
 	}
 
-	item := q.front
-	for item.next != nil {
-		item = item.next
-	}
-	return item
+	return q.back
 }
 
 // Peek the n-th item inserted into the queue
@@ -54,20 +53,7 @@ func (q *Queue[T]) Peek(index int) *QueueItem[T] {
 
 // Return the amount of items in the queue
 func (q *Queue[T]) Len() int {
-	if q.front == nil {
-		return 0
-	}
-
-	length := 0
-
-	item := q.front
-	length += 1
-	for item.next != nil {
-		item = item.next
-		length += 1
-	}
-
-	return length
+	return q.length
 }
 
 // Remove the first item from the start of the queue
@@ -79,6 +65,12 @@ func (q *Queue[T]) Dequeue() *QueueItem[T] {
 	item := q.front
 	item.previous = nil
 	q.front = item.next
+	if q.front == nil {
+		q.back = nil
+	} else {
+		q.front.previous = nil
+	}
+	q.length -= 1
 
 	return item
 }
@@ -89,18 +81,17 @@ func (q *Queue[T]) Queue(value T) {
 		Value: value,
 	}
 
+	q.length += 1
+
 	if q.front == nil {
 		q.front = item
+		q.back = item
 		return
 	}
 
-	last := q.front
-	for last.next != nil {
-		last = last.next
-	}
-
-	last.next = item
-	item.previous = last
+	q.back.next = item
+	item.previous = q.back
+	q.back = item
 }
 
 // Insert an item into the front of the queue.
@@ -110,8 +101,11 @@ func (q *Queue[T]) QueueFront(value T) {
 		Value: value,
 	}
 
+	q.length += 1
+
 	if q.front == nil {
 		q.front = item
+		q.back = item
 		return
 	}
 
@@ -56,4 +56,39 @@ func TestQueue(t *testing.T) {
 
 		assert.Equal(t, 3, q.Len(), "Should have non-zero element count")
 	})
+	t.Run("should keep the last item", func(t *testing.T) {
+		q := New[int]()
+		assert.Nil(t, q.Last(), "Should be empty")
+
+		q.QueueFront(2)
+		q.QueueFront(1)
+		q.Queue(3)
+		assert.Equal(t, 3, q.Len(), "Should have non-zero element count")
+		assert.Equal(t, 1, q.First().Value, "Should be equal")
+		assert.Equal(t, 3, q.Last().Value, "Should be equal")
+
+		q.Dequeue()
+		q.Dequeue()
+		assert.Equal(t, 3, q.Last().Value, "Should be equal")
+
+		q.Dequeue()
+		assert.Nil(t, q.First(), "Should be empty")
+		assert.Nil(t, q.Last(), "Should be empty")
+		assert.Equal(t, 0, q.Len(), "Should be empty")
+
+		q.Queue(4)
+		assert.Equal(t, 4, q.First().Value, "Should be equal")
+		assert.Equal(t, 4, q.Last().Value, "Should be equal")
+		assert.Equal(t, 1, q.Len(), "Should have non-zero element count")
+	})
+	t.Run("should flatten in order", func(t *testing.T) {
+		q := New[int]()
+		q.Queue(2)
+		q.QueueFront(1)
+		q.Queue(3)
+
+		assert.Equal(t, []int{1, 2, 3}, q.Flatten(), "Should be equal")
+		assert.Equal(t, 0, q.Len(), "Should be empty")
+		assert.Nil(t, q.Last(), "Should be empty")
+	})
 }
@@ -8,6 +8,11 @@ import (
 var booleanTrueStrings = [...]string{"yes", "true", "right", "correct"}
 var booleanFalseStrings = [...]string{"no", "false", "wrong", "incorrect"}
 
+// Returns every word that is read as a boolean value.
+func BooleanStrings() []string {
+	return slices.Concat(booleanTrueStrings[:], booleanFalseStrings[:])
+}
+
 func AsBooleanValue(str string) (bool, bool) {
 	if slices.Contains(booleanTrueStrings[:], str) {
 		return true, true
@@ -0,0 +1,59 @@
+package utilities
+
+import "strings"
+
+// Returns the Levenshtein distance between two strings.
+func EditDistance(a string, b string) int {
+	ar := []rune(a)
+	br := []rune(b)
+
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for idx := range previous {
+		previous[idx] = idx
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(
+				previous[j]+1,
+				current[j-1]+1,
+				previous[j-1]+cost,
+			)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(br)]
+}
+
+// Returns the candidate closest to the target string, ignoring casing.
+//
+// A candidate is only considered if it is within a third of the target's length,
+// and exact matches are ignored. If no candidate is close enough, the second
+// return value will be false.
+func ClosestString(target string, candidates []string) (string, bool) {
+	threshold := max(1, len(target)/3)
+
+	closest := ""
+	closestDistance := threshold + 1
+	for _, candidate := range candidates {
+		if candidate == target {
+			continue
+		}
+
+		distance := EditDistance(strings.ToLower(target), strings.ToLower(candidate))
+		if distance < closestDistance {
+			closest = candidate
+			closestDistance = distance
+		}
+	}
+
+	return closest, closest != ""
+}
@@ -0,0 +1,91 @@
+package utilities
+
+import (
+	"sort"
+	"strings"
+)
+
+// A position in the source code
+type Position struct {
+	// Character index
+	Index int `json:"index"`
+	// 1-based line number
+	Line int `json:"line"`
+	// 1-based column number
+	Column int `json:"column"`
+}
+
+// A range in the source code. End is the position right after the last character.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// A SourceFile is the source code of a report, along with where each of its lines start.
+//
+// The line offsets are only computed once, so that positions can be resolved
+// without scanning the source code again.
+type SourceFile struct {
+	Content string
+
+	// Character index of the start of each line
+	lines []int
+}
+
+func NewSourceFile(content string) *SourceFile {
+	lines := []int{0}
+	for idx := 0; idx < len(content); idx++ {
+		if content[idx] == '\n' {
+			lines = append(lines, idx+1)
+		}
+	}
+
+	return &SourceFile{
+		Content: content,
+		lines:   lines,
+	}
+}
+
+// Returns the amount of lines in the source code.
+func (f *SourceFile) LineCount() int {
+	return len(f.lines)
+}
+
+// Returns the content of a 1-based line, without its line ending.
+func (f *SourceFile) Line(line int) string {
+	if line < 1 || line > len(f.lines) {
+		return ""
+	}
+
+	start := f.lines[line-1]
+	end := len(f.Content)
+	if line < len(f.lines) {
+		end = f.lines[line] - 1
+	}
+
+	return strings.TrimSuffix(f.Content[start:end], "\r")
+}
+
+// Returns the position of a character index. The index is clamped to the source code.
+func (f *SourceFile) Position(index int) Position {
+	index = max(0, min(index, len(f.Content)))
+
+	// The last line which starts at or before the index
+	line := sort.Search(len(f.lines), func(idx int) bool {
+		return f.lines[idx] > index
+	})
+
+	return Position{
+		Index:  index,
+		Line:   line,
+		Column: index - f.lines[line-1] + 1,
+	}
+}
+
+// Returns the range of the characters from start, up to start+length.
+func (f *SourceFile) Range(start int, length int) Range {
+	return Range{
+		Start: f.Position(start),
+		End:   f.Position(start + length),
+	}
+}
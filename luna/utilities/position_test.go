@@ -0,0 +1,40 @@
+package utilities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceFile(t *testing.T) {
+	file := NewSourceFile("Dear\r\n\tPrincess\n\nCelestia")
+
+	t.Run("should resolve positions", func(t *testing.T) {
+		assert.Equal(t, Position{Index: 0, Line: 1, Column: 1}, file.Position(0))
+		assert.Equal(t, Position{Index: 5, Line: 1, Column: 6}, file.Position(5))
+		assert.Equal(t, Position{Index: 7, Line: 2, Column: 2}, file.Position(7))
+		assert.Equal(t, Position{Index: 16, Line: 3, Column: 1}, file.Position(16))
+		assert.Equal(t, Position{Index: 25, Line: 4, Column: 9}, file.Position(25))
+	})
+
+	t.Run("should clamp positions outside of the source", func(t *testing.T) {
+		assert.Equal(t, Position{Index: 0, Line: 1, Column: 1}, file.Position(-1))
+		assert.Equal(t, Position{Index: 25, Line: 4, Column: 9}, file.Position(100))
+	})
+
+	t.Run("should resolve ranges", func(t *testing.T) {
+		assert.Equal(t, Range{
+			Start: Position{Index: 7, Line: 2, Column: 2},
+			End:   Position{Index: 17, Line: 4, Column: 1},
+		}, file.Range(7, 10))
+	})
+
+	t.Run("should return the content of a line", func(t *testing.T) {
+		assert.Equal(t, 4, file.LineCount())
+		assert.Equal(t, "Dear", file.Line(1))
+		assert.Equal(t, "\tPrincess", file.Line(2))
+		assert.Equal(t, "", file.Line(3))
+		assert.Equal(t, "Celestia", file.Line(4))
+		assert.Equal(t, "", file.Line(5))
+	})
+}
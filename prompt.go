@@ -0,0 +1,159 @@
+package fim
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/celestia"
+)
+
+// Returned by a prompt when there is no scripted input left.
+// The run fails at the position of the prompt statement.
+var ErrOutOfInput = celestia.ErrOutOfInput
+
+// A Prompt answers the prompt statements of a report.
+//
+// Returning io.EOF reports the end of the input, which is different from an
+// empty answer. Without a MoreInput, 'there is more to read' is true until then.
+type Prompt = func(prompt string) (string, error)
+
+// A MoreInput reports whether the next prompt has input to read, which the
+// report checks with 'there is more to read'.
+type MoreInput = func() bool
+
+// Checks if the reader has anything left to read, without reading it.
+func hasMore(reader *bufio.Reader) bool {
+	_, err := reader.Peek(1)
+	return err == nil
+}
+
+// Read a line without its line ending. Returns io.EOF if there is nothing left.
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Create a prompt that writes to stdout, and reads a line from stdin.
+//
+// The same reader is kept between prompts, so that piped input is not lost.
+// Once stdin is empty, the prompt returns io.EOF.
+func StdioPrompt(stdin io.Reader, stdout io.Writer) (Prompt, MoreInput) {
+	reader := bufio.NewReader(stdin)
+
+	prompt := func(prompt string) (string, error) {
+		io.WriteString(stdout, prompt)
+
+		return readLine(reader)
+	}
+
+	more := func() bool {
+		return hasMore(reader)
+	}
+
+	return prompt, more
+}
+
+// Create a prompt that writes to stdout, and answers with each line of input.
+//
+// Once every line is used, the prompt fails with ErrOutOfInput.
+func InputPrompt(input io.Reader, stdout io.Writer) (Prompt, MoreInput) {
+	reader := bufio.NewReader(input)
+
+	prompt := func(prompt string) (string, error) {
+		io.WriteString(stdout, prompt)
+
+		response, err := readLine(reader)
+		if errors.Is(err, io.EOF) {
+			return "", ErrOutOfInput
+		}
+
+		return response, err
+	}
+
+	more := func() bool {
+		return hasMore(reader)
+	}
+
+	return prompt, more
+}
+
+// A PromptRecord is one prompt and its answer, as stored by RecordPrompt.
+type PromptRecord struct {
+	Prompt string `json:"prompt"`
+	Answer string `json:"answer"`
+	// Whether the prompt has reached the end of the input
+	EndOfInput bool `json:"eof,omitempty"`
+}
+
+// Wrap a prompt so that every prompt and its answer is written to w,
+// one JSON object per line.
+func RecordPrompt(prompt Prompt, w io.Writer) Prompt {
+	encoder := json.NewEncoder(w)
+
+	return func(text string) (string, error) {
+		answer, err := prompt(text)
+		endOfInput := errors.Is(err, io.EOF)
+		if err != nil && !endOfInput {
+			return "", err
+		}
+
+		if err := encoder.Encode(PromptRecord{Prompt: text, Answer: answer, EndOfInput: endOfInput}); err != nil {
+			return "", err
+		}
+		if endOfInput {
+			return "", io.EOF
+		}
+		return answer, nil
+	}
+}
+
+// Create a prompt that writes to stdout, and answers with the records
+// stored by RecordPrompt, in order.
+//
+// The prompts must be asked in the same order as they were recorded.
+// Once every record is used, the prompt fails with ErrOutOfInput.
+func ReplayPrompt(r io.Reader, stdout io.Writer) (Prompt, MoreInput, error) {
+	records := make([]PromptRecord, 0)
+
+	decoder := json.NewDecoder(r)
+	for decoder.More() {
+		var record PromptRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, nil, fmt.Errorf("Invalid prompt record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	prompt := func(prompt string) (string, error) {
+		io.WriteString(stdout, prompt)
+
+		if len(records) == 0 {
+			return "", ErrOutOfInput
+		}
+
+		record := records[0]
+		if record.Prompt != prompt {
+			return "", fmt.Errorf("Expected recorded prompt %q, got %q", record.Prompt, prompt)
+		}
+
+		records = records[1:]
+		if record.EndOfInput {
+			return "", io.EOF
+		}
+		return record.Answer, nil
+	}
+
+	more := func() bool {
+		return len(records) > 0 && !records[0].EndOfInput
+	}
+
+	return prompt, more, nil
+}
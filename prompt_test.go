@@ -0,0 +1,147 @@
+package fim
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt(t *testing.T) {
+	source := `Dear Princess Celestia: Prompts!
+	Today I learned how to ask twice!
+		Did you know that Applejack is a word?
+		I asked Applejack: "First? ".
+		I said Applejack!
+		I asked Applejack: "Second? ".
+		I said Applejack!
+	That's all about how to ask twice.
+	Your faithful student, Twilight Sparkle.`
+
+	program, err := Compile(source, CompileOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("should answer with each line of input", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		_, err := program.Run(context.Background(), RunOptions{
+			Stdout: output,
+			Prompt: withoutMoreInput(InputPrompt(strings.NewReader("Apple\r\nJack"), output)),
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "First? Apple\nSecond? Jack\n", output.String())
+	})
+
+	t.Run("should fail at the prompt when out of input", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{
+			Prompt: withoutMoreInput(InputPrompt(strings.NewReader("Apple\n"), &bytes.Buffer{})),
+		})
+		if !assert.Error(t, err) {
+			return
+		}
+		assert.Contains(t, err.Error(), ErrOutOfInput.Error())
+		assert.Equal(t, STAGE_RUNTIME, result.Error.Stage)
+		assert.True(t, result.Error.HasOrigin)
+		assert.Equal(t, strings.Index(source, `I asked Applejack: "Second? "`), result.Error.Index)
+		assert.Equal(t, 6, result.Error.Line)
+		assert.Equal(t, 3, result.Error.Column)
+		assert.Contains(t, err.Error(), "[line 6:3]")
+	})
+
+	t.Run("should replay a recording", func(t *testing.T) {
+		recording := &bytes.Buffer{}
+		recorded, err := program.Run(context.Background(), RunOptions{
+			Prompt: RecordPrompt(withoutMoreInput(InputPrompt(strings.NewReader("Apple\nJack\n"), &bytes.Buffer{})), recording),
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "{\"prompt\":\"First? \",\"answer\":\"Apple\"}\n{\"prompt\":\"Second? \",\"answer\":\"Jack\"}\n", recording.String())
+
+		output := &bytes.Buffer{}
+		prompt, _, err := ReplayPrompt(recording, output)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = program.Run(context.Background(), RunOptions{Stdout: output, Prompt: prompt})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "First? Apple\nSecond? Jack\n", output.String())
+		assert.Equal(t, "Apple\nJack\n", recorded.Output)
+	})
+
+	t.Run("should fail to replay a different prompt", func(t *testing.T) {
+		prompt, _, err := ReplayPrompt(strings.NewReader(`{"prompt":"Name? ","answer":"Apple"}`), &bytes.Buffer{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = program.Run(context.Background(), RunOptions{Prompt: prompt})
+		assert.ErrorContains(t, err, "Expected recorded prompt")
+	})
+
+	t.Run("should not read an invalid recording", func(t *testing.T) {
+		_, _, err := ReplayPrompt(strings.NewReader(`{"prompt":`), &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+
+	t.Run("should read every line until the end of input", func(t *testing.T) {
+		echo, err := Compile(`Dear Princess Celestia: Echo!
+		Today I learned how to echo every line!
+			Did you know that Line is a word?
+			As long as there is more to read,
+				I asked Line: "".
+				I said "> " plus Line!
+			That's what I did.
+		That's all about how to echo every line.
+		Your faithful student, Twilight Sparkle.`, CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		result, err := echo.Run(context.Background(), RunOptions{Stdin: strings.NewReader("a\nb\n")})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "> a\n> b\n", result.Output)
+
+		result, err = echo.Run(context.Background(), RunOptions{Stdin: strings.NewReader("Apple\n\nJack")})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "> Apple\n> \n> Jack\n", result.Output)
+
+		recording := &bytes.Buffer{}
+		prompt, more := InputPrompt(strings.NewReader("a\nb\n"), &bytes.Buffer{})
+		result, err = echo.Run(context.Background(), RunOptions{
+			Prompt:    RecordPrompt(prompt, recording),
+			MoreInput: more,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "> a\n> b\n", result.Output)
+
+		prompt, more, err = ReplayPrompt(recording, &bytes.Buffer{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		result, err = echo.Run(context.Background(), RunOptions{Prompt: prompt, MoreInput: more})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "> a\n> b\n", result.Output)
+	})
+}
+
+// Returns the prompt without its MoreInput, so that the end of input is only found by reading it.
+func withoutMoreInput(prompt Prompt, _ MoreInput) Prompt {
+	return prompt
+}
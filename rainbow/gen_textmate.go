@@ -0,0 +1,27 @@
+//go:build ignore
+
+// Generates the TextMate grammar of FiM++ reports.
+//
+// Run with 'go generate ./rainbow'
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"git.jaezmien.com/Jaezmien/fim/rainbow"
+)
+
+func main() {
+	file, err := os.Create(rainbow.TextMateGrammarFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := rainbow.WriteTextMateGrammar(file); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
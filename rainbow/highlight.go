@@ -0,0 +1,96 @@
+package rainbow
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+)
+
+// The prefix of every CSS class, e.g. 'fim-keyword'
+const ClassPrefix = "fim-"
+
+// The default colors of each CSS class.
+const Stylesheet = `pre.fim { background: #fbf8ff; color: #222; padding: 0.5rem 1rem; overflow-x: auto; }
+.fim-keyword { color: #6a3d9a; font-weight: bold; }
+.fim-type { color: #1f78b4; }
+.fim-literal { color: #b15928; }
+.fim-comment { color: #808080; font-style: italic; }
+.fim-identifier { color: #33a02c; }
+.fim-operator { color: #e31a1c; }
+`
+
+// The ANSI escape code of each category.
+var ansiColors = map[token.TokenCategory]string{
+	token.TokenCategory_Keyword:    "\x1b[1;35m",
+	token.TokenCategory_Type:       "\x1b[36m",
+	token.TokenCategory_Literal:    "\x1b[33m",
+	token.TokenCategory_Comment:    "\x1b[90m",
+	token.TokenCategory_Identifier: "\x1b[32m",
+	token.TokenCategory_Operator:   "\x1b[31m",
+}
+
+const ansiReset = "\x1b[0m"
+
+// Write the source code as an HTML '<pre>' block, where each token is
+// wrapped in a span with the CSS class of its category.
+//
+// The source code does not have to be a valid report, since only the
+// lexer is used.
+func WriteHTML(w io.Writer, source string) error {
+	sb := &strings.Builder{}
+	sb.WriteString(`<pre class="fim"><code>`)
+
+	for _, t := range twilight.ParseWithTrivia(source) {
+		c := t.Type.Category()
+		if c == token.TokenCategory_None {
+			sb.WriteString(html.EscapeString(t.Value))
+			continue
+		}
+
+		fmt.Fprintf(sb, `<span class="%s%s">%s</span>`, ClassPrefix, c, html.EscapeString(t.Value))
+	}
+
+	sb.WriteString("</code></pre>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Write the source code as a standalone HTML page, including the stylesheet.
+func WriteHTMLPage(w io.Writer, source string, title string) error {
+	_, err := fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", html.EscapeString(title), Stylesheet)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteHTML(w, source); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "</body>\n</html>\n")
+	return err
+}
+
+// Write the source code with ANSI escape codes, to be shown in a terminal.
+func WriteANSI(w io.Writer, source string) error {
+	sb := &strings.Builder{}
+
+	for _, t := range twilight.ParseWithTrivia(source) {
+		color, ok := ansiColors[t.Type.Category()]
+		if !ok {
+			sb.WriteString(t.Value)
+			continue
+		}
+
+		sb.WriteString(color)
+		sb.WriteString(t.Value)
+		sb.WriteString(ansiReset)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
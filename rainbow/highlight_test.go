@@ -0,0 +1,84 @@
+package rainbow
+
+import (
+	"bytes"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	source := `Dear Princess Celestia: Highlights!
+	(Counts <apples>)
+	Today I learned how to count!
+		Did you know that Spike is the number 1?
+		I said "Spike & " plus Spike!
+	That's all about how to count.
+	Your faithful student, Twilight Sparkle.`
+
+	tags := regexp.MustCompile(`<[^>]*>`)
+
+	t.Run("should wrap each token in its category", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, WriteHTML(output, source)) {
+			return
+		}
+
+		assert.Contains(t, output.String(), `<span class="fim-keyword">Dear Princess Celestia:</span> <span class="fim-identifier">Highlights</span>!`)
+		assert.Contains(t, output.String(), `<span class="fim-comment">(Counts &lt;apples&gt;)</span>`)
+		assert.Contains(t, output.String(), `<span class="fim-keyword">Did you know that</span> <span class="fim-identifier">Spike</span>`)
+		assert.Contains(t, output.String(), `<span class="fim-type">the number</span> <span class="fim-literal">1</span>?`)
+		assert.Contains(t, output.String(), `<span class="fim-literal">&#34;Spike &amp; &#34;</span> <span class="fim-operator">plus</span>`)
+		assert.Contains(t, output.String(), `<span class="fim-keyword">That&#39;s all about</span>`)
+	})
+
+	t.Run("should keep the source code", func(t *testing.T) {
+		files, err := filepath.Glob("../samples/*.fim")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, file := range append(files, "") {
+			sample := source
+			if file != "" {
+				content, err := os.ReadFile(file)
+				if !assert.NoError(t, err) {
+					return
+				}
+				sample = string(content)
+			}
+
+			output := &bytes.Buffer{}
+			if !assert.NoError(t, WriteHTML(output, sample)) {
+				return
+			}
+			assert.Equal(t, sample+"\n", html.UnescapeString(tags.ReplaceAllString(output.String(), "")), file)
+		}
+	})
+
+	t.Run("should write a standalone page", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, WriteHTMLPage(output, source, "<count>")) {
+			return
+		}
+
+		assert.Contains(t, output.String(), "<title>&lt;count&gt;</title>")
+		assert.Contains(t, output.String(), ".fim-keyword {")
+		assert.Contains(t, output.String(), `<pre class="fim"><code>`)
+	})
+
+	t.Run("should write ANSI escape codes", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, WriteANSI(output, source)) {
+			return
+		}
+
+		assert.Contains(t, output.String(), "\x1b[1;35mDear Princess Celestia:\x1b[0m \x1b[32mHighlights\x1b[0m!\n")
+		assert.Contains(t, output.String(), "\x1b[90m(Counts <apples>)\x1b[0m")
+		assert.Equal(t, source, regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(output.String(), ""))
+	})
+}
@@ -0,0 +1,132 @@
+package rainbow
+
+//go:generate go run gen_textmate.go
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/twilight/parsers"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// The file that the TextMate grammar is generated into, with 'go generate'
+const TextMateGrammarFile = "fim.tmLanguage.json"
+
+// The TextMate scope of each keyword category.
+var textMateScopes = map[token.TokenCategory]string{
+	token.TokenCategory_Keyword:  "keyword.control.fim",
+	token.TokenCategory_Type:     "storage.type.fim",
+	token.TokenCategory_Operator: "keyword.operator.fim",
+}
+
+type textMatePattern struct {
+	Name     string            `json:"name,omitempty"`
+	Match    string            `json:"match,omitempty"`
+	Begin    string            `json:"begin,omitempty"`
+	End      string            `json:"end,omitempty"`
+	Include  string            `json:"include,omitempty"`
+	Patterns []textMatePattern `json:"patterns,omitempty"`
+}
+
+type textMateGrammar struct {
+	Name       string                     `json:"name"`
+	ScopeName  string                     `json:"scopeName"`
+	FileTypes  []string                   `json:"fileTypes"`
+	Patterns   []textMatePattern          `json:"patterns"`
+	Repository map[string]textMatePattern `json:"repository"`
+}
+
+// Returns a pattern that matches any of the words as a whole.
+//
+// Longer words are tried first, so that 'is not' is not matched as 'is'.
+func matchWords(words []string) string {
+	words = slices.Clone(words)
+	slices.SortFunc(words, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	words = slices.Compact(words)
+
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+
+	return `(?<![\w'])(?:` + strings.Join(words, "|") + `)(?![\w'])`
+}
+
+// Returns the phrases of every keyword in the category.
+func keywordPhrases(category token.TokenCategory) []string {
+	phrases := make([]string, 0)
+	for _, keyword := range parsers.Keywords {
+		if keyword.Type.Category() == category {
+			phrases = append(phrases, keyword.Text()...)
+		}
+	}
+	return phrases
+}
+
+func newTextMateGrammar() *textMateGrammar {
+	grammar := &textMateGrammar{
+		Name:      "FiM++",
+		ScopeName: "source.fim",
+		FileTypes: []string{"fim"},
+		Patterns:  make([]textMatePattern, 0),
+		Repository: map[string]textMatePattern{
+			"comments": {Patterns: []textMatePattern{
+				{Name: "comment.line.shebang.fim", Match: `\A#!.*$`},
+				{Name: "comment.line.postscript.fim", Match: `(?<![\w'])P\.(?:S\.)+(?= ).*$`},
+				{Name: "comment.block.fim", Match: `\([^)]*\)`},
+			}},
+			"strings": {
+				Name:  "string.quoted.double.fim",
+				Begin: `"`,
+				End:   `"|$`,
+				Patterns: []textMatePattern{
+					{Name: "constant.character.escape.fim", Match: `\\.`},
+				},
+			},
+			"characters": {Name: "string.quoted.single.fim", Match: `'(?:\\.|[^'\\])'`},
+			"numbers":    {Name: "constant.numeric.fim", Match: `(?<![\w.])-?\d+(?:\.\d+)?(?![\w])`},
+			"constants":  {Name: "constant.language.fim", Match: matchWords(append(luna.BooleanStrings(), "nothing"))},
+		},
+	}
+
+	for _, name := range []string{"comments", "strings", "characters"} {
+		grammar.Patterns = append(grammar.Patterns, textMatePattern{Include: "#" + name})
+	}
+
+	// Keywords come before the types and operators, so that e.g. 'is now' is not matched as 'is'
+	for _, category := range []token.TokenCategory{token.TokenCategory_Keyword, token.TokenCategory_Type, token.TokenCategory_Operator} {
+		name := category.String() + "s"
+
+		grammar.Repository[name] = textMatePattern{
+			Name:  textMateScopes[category],
+			Match: matchWords(keywordPhrases(category)),
+		}
+		grammar.Patterns = append(grammar.Patterns, textMatePattern{Include: "#" + name})
+	}
+
+	for _, name := range []string{"numbers", "constants"} {
+		grammar.Patterns = append(grammar.Patterns, textMatePattern{Include: "#" + name})
+	}
+
+	return grammar
+}
+
+// Write a TextMate grammar for FiM++ reports as JSON.
+//
+// The keywords are taken from the same phrases that the lexer uses.
+func WriteTextMateGrammar(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(newTextMateGrammar())
+}
@@ -0,0 +1,57 @@
+package rainbow
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"git.jaezmien.com/Jaezmien/fim/twilight/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextMateGrammar(t *testing.T) {
+	output := &bytes.Buffer{}
+	if !assert.NoError(t, WriteTextMateGrammar(output)) {
+		return
+	}
+
+	t.Run("should match the generated grammar", func(t *testing.T) {
+		generated, err := os.ReadFile(TextMateGrammarFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, string(generated), output.String(), "Run 'go generate ./rainbow' to update the grammar")
+	})
+
+	t.Run("should include every keyword phrase", func(t *testing.T) {
+		var grammar textMateGrammar
+		if !assert.NoError(t, json.Unmarshal(output.Bytes(), &grammar)) {
+			return
+		}
+		assert.Equal(t, "source.fim", grammar.ScopeName)
+
+		words := regexp.MustCompile(`\(\?:(.*)\)\(\?!`)
+		phrases := func(name string) []string {
+			match := words.FindStringSubmatch(grammar.Repository[name].Match)
+			if !assert.Len(t, match, 2, name) {
+				return nil
+			}
+			return strings.Split(match[1], "|")
+		}
+
+		patterns := slices.Concat(phrases("keywords"), phrases("types"), phrases("operators"))
+		for _, keyword := range parsers.Keywords {
+			for _, phrase := range keyword.Text() {
+				assert.Contains(t, patterns, regexp.QuoteMeta(phrase), keyword.Type.String())
+			}
+		}
+
+		assert.Contains(t, phrases("keywords"), "Did you know that")
+		assert.Contains(t, phrases("types"), "many numbers")
+		assert.Contains(t, phrases("operators"), "isn't equal to")
+	})
+}
@@ -0,0 +1,294 @@
+package rarity
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/spike"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+)
+
+// The string used for one level of indentation.
+const Indent = "\t"
+
+// Token types that start a block, and indent the lines that follow.
+var openerTypes = []token.TokenType{
+	token.TokenType_FunctionHeader,
+	token.TokenType_FunctionMain,
+	token.TokenType_IfClause,
+	token.TokenType_ElseClause,
+	token.TokenType_WhileClause,
+	token.TokenType_ForEveryClause,
+}
+
+// Token types that end a block, and are dedented themselves.
+var closerTypes = []token.TokenType{
+	token.TokenType_FunctionFooter,
+	token.TokenType_ElseClause,
+	token.TokenType_IfEndClause,
+	token.TokenType_KeywordStatementEnd,
+}
+
+// Token types that start a top-level item, which are preceded by a blank line.
+var itemStartTypes = []token.TokenType{
+	token.TokenType_ReportFooter,
+	token.TokenType_FunctionHeader,
+	token.TokenType_FunctionMain,
+}
+
+// Token types that end a top-level item, which are followed by a blank line.
+var itemEndTypes = []token.TokenType{
+	token.TokenType_ReportHeader,
+	token.TokenType_ReportFooter,
+	token.TokenType_FunctionFooter,
+}
+
+type lineKind uint
+
+const (
+	LINE_CODE lineKind = iota
+	LINE_COMMENT
+	LINE_BLANK
+)
+
+type line struct {
+	kind  lineKind
+	depth int
+	text  string
+
+	// The type of the first token of a code line
+	first token.TokenType
+}
+
+type formatter struct {
+	tokens []*token.Token
+	index  int
+
+	lines []line
+	depth int
+}
+
+func isComment(t *token.Token) bool {
+	return t.Type == token.TokenType_CommentParen || t.Type == token.TokenType_CommentPostScript || t.Type == token.TokenType_CommentShebang
+}
+
+func (f *formatter) peek(offset int) *token.Token {
+	if f.index+offset >= len(f.tokens) {
+		return nil
+	}
+	return f.tokens[f.index+offset]
+}
+
+// Returns the last line that is not a blank line.
+func (f *formatter) previousLine() *line {
+	for idx := len(f.lines) - 1; idx >= 0; idx-- {
+		if f.lines[idx].kind != LINE_BLANK {
+			return &f.lines[idx]
+		}
+	}
+	return nil
+}
+
+// Decide whether a blank line should separate the previous line from a new line.
+func (f *formatter) wantsBlankLine(kind lineKind, first token.TokenType, newlines int) bool {
+	previous := f.previousLine()
+	if previous == nil {
+		return false
+	}
+
+	sourceBlank := newlines >= 2
+
+	if kind == LINE_CODE && slices.Contains(closerTypes, first) {
+		return false
+	}
+
+	if f.depth == 0 {
+		if previous.kind == LINE_CODE && previous.depth == 0 && slices.Contains(itemEndTypes, previous.first) {
+			return true
+		}
+		if kind == LINE_CODE && previous.kind == LINE_CODE && slices.Contains(itemStartTypes, first) {
+			return true
+		}
+		return sourceBlank
+	}
+
+	if previous.kind == LINE_CODE && slices.Contains(openerTypes, previous.first) && previous.depth == f.depth-1 {
+		return false
+	}
+
+	return sourceBlank
+}
+
+func (f *formatter) addLine(kind lineKind, first token.TokenType, text string, newlines int) {
+	if kind == LINE_CODE && slices.Contains(closerTypes, first) {
+		f.depth = max(0, f.depth-1)
+	}
+
+	if f.wantsBlankLine(kind, first, newlines) {
+		f.lines = append(f.lines, line{kind: LINE_BLANK})
+	}
+
+	f.lines = append(f.lines, line{
+		kind:  kind,
+		depth: f.depth,
+		text:  text,
+		first: first,
+	})
+
+	if kind == LINE_CODE && slices.Contains(openerTypes, first) {
+		f.depth += 1
+	}
+}
+
+// Check if a punctuation token ends the statement that started with the token type.
+func endsStatement(first token.TokenType, punctuation *token.Token, hasParameters bool, previous *token.Token) bool {
+	switch first {
+	case token.TokenType_IfClause, token.TokenType_ElseClause, token.TokenType_WhileClause:
+		if punctuation.Value == "," && hasParameters {
+			return previous != nil && previous.Type == token.TokenType_KeywordThen
+		}
+		return true
+	case token.TokenType_FunctionHeader, token.TokenType_FunctionMain, token.TokenType_ForEveryClause:
+		return punctuation.Value != ","
+	default:
+		return punctuation.Value != "," && punctuation.Value != ":"
+	}
+}
+
+// Consume the tokens of a single statement, and return its text.
+func (f *formatter) consumeStatement() string {
+	sb := strings.Builder{}
+
+	first := f.peek(0)
+	hasParameters := false
+	pendingSpace := false
+	var previous *token.Token
+
+	for f.index < len(f.tokens) {
+		t := f.peek(0)
+
+		if t.Type == token.TokenType_EndOfFile {
+			break
+		}
+		if t.Type == token.TokenType_Whitespace || t.Type == token.TokenType_NewLine {
+			pendingSpace = true
+			f.index++
+			continue
+		}
+
+		if pendingSpace && sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		pendingSpace = false
+
+		sb.WriteString(t.Value)
+		f.index++
+
+		if t.Type == token.TokenType_FunctionParameter {
+			hasParameters = true
+		}
+
+		if t.Type == token.TokenType_Punctuation && endsStatement(first.Type, t, hasParameters, previous) {
+			// Keep trailing punctuations (e.g.: '...')
+			for f.peek(0) != nil && f.peek(0).Type == token.TokenType_Punctuation {
+				sb.WriteString(f.peek(0).Value)
+				f.index++
+			}
+
+			// Keep comments that trail on the same line
+			offset := 0
+			for f.peek(offset) != nil && f.peek(offset).Type == token.TokenType_Whitespace {
+				offset++
+			}
+			if f.peek(offset) != nil && isComment(f.peek(offset)) {
+				sb.WriteString(" ")
+				sb.WriteString(f.peek(offset).Value)
+				f.index += offset + 1
+			}
+
+			break
+		}
+
+		previous = t
+	}
+
+	return sb.String()
+}
+
+func (f *formatter) format() string {
+	newlines := 0
+
+	for f.index < len(f.tokens) {
+		t := f.peek(0)
+
+		switch {
+		case t.Type == token.TokenType_EndOfFile:
+			f.index++
+		case t.Type == token.TokenType_NewLine:
+			newlines++
+			f.index++
+		case t.Type == token.TokenType_Whitespace:
+			f.index++
+		case isComment(t):
+			f.addLine(LINE_COMMENT, t.Type, t.Value, newlines)
+			newlines = 0
+			f.index++
+		default:
+			text := f.consumeStatement()
+			f.addLine(LINE_CODE, t.Type, text, newlines)
+			newlines = 0
+		}
+	}
+
+	sb := strings.Builder{}
+	for _, l := range f.lines {
+		if l.kind != LINE_BLANK {
+			sb.WriteString(strings.Repeat(Indent, l.depth))
+			sb.WriteString(l.text)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// Checks if both sources have the same meaningful tokens.
+func sameTokens(a string, b string) bool {
+	return slices.EqualFunc(twilight.Parse(a), twilight.Parse(b), func(x *token.Token, y *token.Token) bool {
+		return x.Type == y.Type && x.Value == y.Value
+	})
+}
+
+// Rewrite the report into its canonical layout.
+//
+// Nested blocks are indented, each statement is placed in its own line, and
+// paragraphs are separated by a single blank line. Comments are preserved.
+func Format(source string) (string, error) {
+	if _, err := spike.CreateReport(twilight.Parse(source), source); err != nil {
+		return "", err
+	}
+
+	f := &formatter{
+		tokens: twilight.ParseWithTrivia(source),
+		lines:  make([]line, 0),
+	}
+	formatted := f.format()
+
+	if !sameTokens(source, formatted) {
+		return "", errors.New("Formatting would change the meaning of the report")
+	}
+
+	return formatted, nil
+}
+
+// Checks if the report is already in its canonical layout.
+func IsFormatted(source string) (bool, error) {
+	formatted, err := Format(source)
+	if err != nil {
+		return false, err
+	}
+
+	return formatted == source, nil
+}
@@ -0,0 +1,159 @@
+package rarity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("should indent nested blocks", func(t *testing.T) {
+		source := `Dear Princess Celestia: Formatting!
+Today I learned how to format!
+Did you know that Spike is the number 1?
+  If Spike is 1 then,
+I said "One"!
+    Otherwise,
+         I said "Not one"!
+That's what I would do.
+That's all about how to format.
+Your faithful student, Rarity.
+`
+
+		expects := `Dear Princess Celestia: Formatting!
+
+Today I learned how to format!
+	Did you know that Spike is the number 1?
+	If Spike is 1 then,
+		I said "One"!
+	Otherwise,
+		I said "Not one"!
+	That's what I would do.
+That's all about how to format.
+
+Your faithful student, Rarity.
+`
+
+		formatted, err := Format(source)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expects, formatted)
+	})
+
+	t.Run("should keep the shebang line", func(t *testing.T) {
+		source := "#!/usr/bin/env fim\nDear Princess Celestia: Formatting!\nYour faithful student, Rarity.\n"
+		expects := "#!/usr/bin/env fim\nDear Princess Celestia: Formatting!\n\nYour faithful student, Rarity.\n"
+
+		formatted, err := Format(source)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expects, formatted)
+	})
+
+	t.Run("should place one statement per line", func(t *testing.T) {
+		source := `Dear Princess Celestia: Formatting!
+
+Today I learned how to format!
+	Did you know that Spike is the number 1? (Counter)
+	If Spike is 1, I said "One"! That's what I would do.
+That's all about how to format.
+
+Your faithful student, Rarity.
+`
+
+		expects := `Dear Princess Celestia: Formatting!
+
+Today I learned how to format!
+	Did you know that Spike is the number 1? (Counter)
+	If Spike is 1,
+		I said "One"!
+	That's what I would do.
+That's all about how to format.
+
+Your faithful student, Rarity.
+`
+
+		formatted, err := Format(source)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expects, formatted)
+	})
+
+	t.Run("should normalize blank lines between paragraphs", func(t *testing.T) {
+		source := `Dear Princess Celestia: Formatting!
+I learned how to help!
+
+
+	I said "Help"!
+
+
+	I said "Me"!
+That's all about how to help.
+(Main paragraph)
+Today I learned how to format!
+	I remembered how to help.
+That's all about how to format.
+Your faithful student, Rarity.
+P.S. Fabulous!
+`
+
+		expects := `Dear Princess Celestia: Formatting!
+
+I learned how to help!
+	I said "Help"!
+
+	I said "Me"!
+That's all about how to help.
+
+(Main paragraph)
+Today I learned how to format!
+	I remembered how to help.
+That's all about how to format.
+
+Your faithful student, Rarity.
+
+P.S. Fabulous!
+`
+
+		formatted, err := Format(source)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expects, formatted)
+	})
+
+	t.Run("should not format an invalid report", func(t *testing.T) {
+		_, err := Format("Dear Princess Celestia: Formatting!")
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatSamples(t *testing.T) {
+	files, err := filepath.Glob("../samples/*.fim")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		formatted, err := Format(string(data))
+		if !assert.NoError(t, err, file) {
+			continue
+		}
+
+		formattedAgain, err := Format(formatted)
+		if !assert.NoError(t, err, file) {
+			continue
+		}
+		assert.Equal(t, formatted, formattedAgain, "Expected formatting to be stable for %s", file)
+	}
+}
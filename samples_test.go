@@ -1,14 +1,10 @@
-package main
+package fim
 
 import (
-	"bytes"
-	"io"
+	"context"
 	"os"
 	"testing"
 
-	"git.jaezmien.com/Jaezmien/fim/celestia"
-	"git.jaezmien.com/Jaezmien/fim/spike"
-	"git.jaezmien.com/Jaezmien/fim/twilight"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,67 +16,22 @@ type BasicReportOptions struct {
 	Prompt        func(prompt string) (string, error)
 }
 
-func CreateReport(t *testing.T, source string, options BasicReportOptions) (*celestia.Interpreter, bool) {
-	tokens := twilight.Parse(source)
-	report, err := spike.CreateReport(tokens, source)
-
-	if err != nil {
-		if options.Error {
-			return nil, false
-		}
-
-		return nil, assert.NoError(t, err, "handled by spike")
-	}
-
-	interpreter, err := celestia.NewInterpreter(report, source)
-
-	if err != nil {
-		if options.Error {
-			return nil, false
-		}
-
-		return nil, assert.NoError(t, err, "handled pre-celestia")
-	}
-
-	return interpreter, true
-}
-func GetMainParagraph(t *testing.T, interpreter *celestia.Interpreter) (*celestia.Paragraph, bool) {
-	var mainParagraph *celestia.Paragraph
-	for _, paragraph := range interpreter.Paragraphs {
-		if paragraph.Main {
-			mainParagraph = paragraph
-			break
-		}
-	}
-	if !assert.NotNil(t, mainParagraph) {
-		return nil, false
-	}
-
-	return mainParagraph, true
-}
-
 func ExecuteBasicReport(t *testing.T, source string, options BasicReportOptions) {
-	interpreter, ok := CreateReport(t, source, options)
-	if !ok {
+	program, err := Compile(source, CompileOptions{})
+	if options.Error && err != nil {
 		return
 	}
-
-	if options.CompileOnly {
+	if !assert.NoError(t, err, "handled by spike") {
 		return
 	}
 
-	buffer := &bytes.Buffer{}
-	interpreter.Writer = buffer
-	if options.Prompt != nil {
-		interpreter.Prompt = options.Prompt
-	}
-
-	mainParagraph, ok := GetMainParagraph(t, interpreter)
-	if !ok {
+	if options.CompileOnly {
 		return
 	}
 
-	_, err := mainParagraph.Execute()
+	result, err := program.Run(context.Background(), RunOptions{
+		Prompt: options.Prompt,
+	})
 	if options.Error && !assert.Error(t, err, "handled by celestia") {
 		return
 	}
@@ -92,12 +43,7 @@ func ExecuteBasicReport(t *testing.T, source string, options BasicReportOptions)
 		return
 	}
 
-	data, err := io.ReadAll(buffer)
-	if !assert.NoError(t, err) {
-		return
-	}
-
-	if !assert.Equal(t, options.Expects, string(data)) {
+	if !assert.Equal(t, options.Expects, result.Output) {
 		return
 	}
 }
@@ -0,0 +1,104 @@
+package fim
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"git.jaezmien.com/Jaezmien/fim/celestia"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// The current version of the Snapshot format
+const SnapshotVersion = 2
+
+// Returned by RunOptions.Prompt or RunOptions.OnStatement to suspend the run
+var ErrSuspend = errors.New("Report was suspended")
+
+// The snapshot does not match the report it is resumed with
+var ErrSnapshotMismatch = errors.New("Snapshot does not match the report")
+
+// A Snapshot is the state of a suspended run.
+//
+// A snapshot can be stored as JSON, and resumed later with Resume. Resuming
+// restores the variables, and continues from the statement the run was
+// suspended at, so the statements before it are not run again.
+//
+// The statement the run was suspended at is run again from its start, so a
+// prompt is asked again. A run cannot be suspended inside a paragraph that is
+// called from a value, such as 'I said how to count', as the rest of the value
+// cannot be resumed. The values returned by the main paragraphs that finished
+// before the run was suspended are not kept.
+type Snapshot struct {
+	Version int `json:"version"`
+	// SHA-256 hash of the report's source code
+	SourceHash string `json:"source_hash"`
+	// Name of the paragraph that was run. If empty, every main paragraph was run
+	Entry string `json:"entry,omitempty"`
+	// Index of the main paragraph that was being run, if every main paragraph was run
+	Paragraph int `json:"paragraph"`
+
+	// The amount of statements that were executed, including the one the run was suspended at
+	Statements int `json:"statements"`
+	// Where in the source the run was suspended
+	Position Position `json:"position"`
+
+	// Every global and local variable
+	Variables *celestia.VariableSnapshot `json:"variables"`
+	// The statement that each block was running
+	Execution *celestia.ExecutionState `json:"execution"`
+}
+
+// A position in a report's source code
+type Position = luna.Position
+
+// Read a snapshot that was stored as JSON.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, err
+	}
+
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("Unsupported snapshot version %d", snapshot.Version)
+	}
+
+	return snapshot, nil
+}
+
+// Store the snapshot as JSON.
+func (s *Snapshot) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+func (s *Snapshot) validate(p *Program) error {
+	if s.Version != SnapshotVersion {
+		return fmt.Errorf("Unsupported snapshot version %d", s.Version)
+	}
+	if s.SourceHash != hashSource(p.source) {
+		return fmt.Errorf("%w: the source code has changed", ErrSnapshotMismatch)
+	}
+	if s.Statements < 1 || s.Variables == nil || s.Execution == nil {
+		return fmt.Errorf("%w: the snapshot is incomplete", ErrSnapshotMismatch)
+	}
+
+	return nil
+}
+
+// Resume a suspended run from its snapshot.
+//
+// The run options are used the same way as Run, except for the entry
+// paragraph which is taken from the snapshot.
+func (p *Program) Resume(ctx context.Context, snapshot *Snapshot, options RunOptions) (*Result, error) {
+	return p.run(ctx, options, snapshot)
+}
+
+func hashSource(source string) string {
+	hash := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(hash[:])
+}
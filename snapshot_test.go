@@ -0,0 +1,280 @@
+package fim
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.jaezmien.com/Jaezmien/fim/celestia"
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"github.com/stretchr/testify/assert"
+)
+
+const snapshotSource = `Dear Princess Celestia: Snapshots!
+Did you know that Names has many words?
+Today I learned how to greet!
+	Did you know that Name is a word?
+	Did you know that Count is the number 0?
+	I said "Start"!
+	I asked Name: "Name? ".
+	1 of Names is Name.
+	Count got one more.
+	I asked Name: "Name? ".
+	2 of Names is Name.
+	Count got one more.
+	Did you know that First is the word 1 of Names?
+	I said First plus " " plus Name plus " " plus Count!
+That's all about how to greet.
+Your faithful student, Twilight Sparkle.`
+
+// Store and read the snapshot again, as if it was saved to disk.
+func roundtripSnapshot(t *testing.T, snapshot *Snapshot) (*Snapshot, bool) {
+	buffer := &bytes.Buffer{}
+	if !assert.NoError(t, snapshot.Write(buffer)) {
+		return nil, false
+	}
+
+	snapshot, err := ReadSnapshot(buffer)
+	return snapshot, assert.NoError(t, err)
+}
+
+// A prompt that suspends once all of its answers are used.
+func suspendingPrompt(answers ...string) func(prompt string) (string, error) {
+	return func(prompt string) (string, error) {
+		if len(answers) == 0 {
+			return "", ErrSuspend
+		}
+
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	program, err := Compile(snapshotSource, CompileOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("should suspend and resume at a prompt", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{Prompt: suspendingPrompt("Twilight")})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+		assert.Equal(t, "Start\n", result.Output)
+		assert.Equal(t, 10, result.Snapshot.Position.Line)
+
+		snapshot, ok := roundtripSnapshot(t, result.Snapshot)
+		if !ok {
+			return
+		}
+
+		result, err = program.Resume(context.Background(), snapshot, RunOptions{Prompt: suspendingPrompt("Spike")})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Nil(t, result.Snapshot)
+		assert.Equal(t, "Twilight Spike 2\n", result.Output)
+	})
+
+	t.Run("should suspend more than once", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{Prompt: suspendingPrompt()})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+		assert.Equal(t, "Start\n", result.Output)
+
+		result, err = program.Resume(context.Background(), result.Snapshot, RunOptions{Prompt: suspendingPrompt("Twilight")})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+		assert.Equal(t, "", result.Output)
+		assert.Equal(t, 10, result.Snapshot.Position.Line)
+
+		result, err = program.Resume(context.Background(), result.Snapshot, RunOptions{Prompt: suspendingPrompt("Spike")})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Twilight Spike 2\n", result.Output)
+	})
+
+	t.Run("should suspend and resume at a statement", func(t *testing.T) {
+		suspended := false
+		result, err := program.Run(context.Background(), RunOptions{
+			Prompt: suspendingPrompt("Twilight", "Spike"),
+			OnStatement: func(statement node.DynamicNode) error {
+				if !suspended && statement.ToNode().Start == bytes.Index([]byte(snapshotSource), []byte("Count got")) {
+					suspended = true
+					return ErrSuspend
+				}
+				return nil
+			},
+		})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+		assert.Equal(t, "Start\n", result.Output)
+		assert.Equal(t, 9, result.Snapshot.Position.Line)
+
+		snapshot, ok := roundtripSnapshot(t, result.Snapshot)
+		if !ok {
+			return
+		}
+
+		result, err = program.Resume(context.Background(), snapshot, RunOptions{Prompt: suspendingPrompt("Spike")})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Twilight Spike 2\n", result.Output)
+	})
+
+	t.Run("should not resume a different report", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{Prompt: suspendingPrompt()})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+
+		other, err := Compile(snapshotSource+"\n", CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = other.Resume(context.Background(), result.Snapshot, RunOptions{})
+		assert.ErrorIs(t, err, ErrSnapshotMismatch)
+	})
+
+	t.Run("should not resume at a statement that does not exist", func(t *testing.T) {
+		result, err := program.Run(context.Background(), RunOptions{Prompt: suspendingPrompt("Twilight")})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+
+		result.Snapshot.Execution.Frames[0].Statement = 99
+
+		_, err = program.Resume(context.Background(), result.Snapshot, RunOptions{Prompt: suspendingPrompt("Spike")})
+		assert.ErrorIs(t, err, ErrSnapshotMismatch)
+	})
+
+	t.Run("should not read an unsupported version", func(t *testing.T) {
+		_, err := ReadSnapshot(bytes.NewBufferString(`{"version": 0}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestResume(t *testing.T) {
+	t.Run("should not run the statements before the snapshot again", func(t *testing.T) {
+		source := `Dear Princess Celestia: Side effects!
+		Today I learned how to log!
+			Did you know that Home is a word?
+			I fetched Home from "HOME".
+			I appended "x" to "log.txt".
+			Did you know that Name is a word?
+			I asked Name: "Name? ".
+			Did you know that First is the word 1 of Arguments?
+			I said Home plus " " plus Name plus " " plus First!
+		That's all about how to log.
+		Your faithful student, Twilight Sparkle.`
+
+		program, err := Compile(source, CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		sandbox := t.TempDir()
+		result, err := program.Run(context.Background(), RunOptions{
+			Prompt:      suspendingPrompt(),
+			Sandbox:     sandbox,
+			Environment: map[string]string{"HOME": "/home/twilight"},
+			Arguments:   []string{"Apple"},
+		})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+
+		result, err = program.Resume(context.Background(), result.Snapshot, RunOptions{
+			Prompt:  suspendingPrompt("Spike"),
+			Sandbox: sandbox,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "/home/twilight Spike Apple\n", result.Output)
+
+		content, err := os.ReadFile(filepath.Join(sandbox, "log.txt"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "x", string(content))
+		}
+	})
+
+	t.Run("should resume inside loops and paragraphs", func(t *testing.T) {
+		source := `Dear Princess Celestia: Loops!
+		I learned how to ask using the number Round!
+			Did you know that Answer is a word?
+			I asked Answer: "Pony? ".
+			I said Round plus " " plus Answer!
+		That's all about how to ask.
+		Today I learned how to loop!
+			Did you know that Apples has the words "Gala", "Mcintosh"?
+			Did you know that Count is the number 0?
+			As long as Count is 0,
+				Count got one more.
+				For every word Apple in Apples...
+					For every number Round from 1 to 2,
+						If Round is 2 then,
+							I remembered how to ask using Round.
+						That's what I would do.
+					That's what I did.
+				That's what I did.
+			That's what I did.
+			I said "Done " plus Count!
+		That's all about how to loop.
+		Your faithful student, Twilight Sparkle.`
+
+		program, err := Compile(source, CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		result, err := program.Run(context.Background(), RunOptions{Prompt: suspendingPrompt("Twilight")})
+		if !assert.NoError(t, err) || !assert.NotNil(t, result.Snapshot) {
+			return
+		}
+		assert.Equal(t, "2 Twilight\n", result.Output)
+
+		snapshot, ok := roundtripSnapshot(t, result.Snapshot)
+		if !ok {
+			return
+		}
+
+		result, err = program.Resume(context.Background(), snapshot, RunOptions{Prompt: suspendingPrompt("Spike")})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "2 Spike\nDone 1\n", result.Output)
+	})
+
+	t.Run("should not suspend inside a paragraph called from a value", func(t *testing.T) {
+		source := `Dear Princess Celestia: Values!
+		I learned how to ask to get a word!
+			Did you know that Answer is a word?
+			I asked Answer: "Pony? ".
+			Then you get Answer!
+		That's all about how to ask.
+		Today I learned how to greet!
+			I said how to ask!
+		That's all about how to greet.
+		Your faithful student, Twilight Sparkle.`
+
+		program, err := Compile(source, CompileOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		result, err := program.Run(context.Background(), RunOptions{Prompt: suspendingPrompt()})
+		assert.ErrorIs(t, err, celestia.ErrNotResumable)
+		assert.Nil(t, result.Snapshot)
+	})
+}
@@ -3,6 +3,7 @@ package ast
 import (
 	"slices"
 
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
 	"git.jaezmien.com/Jaezmien/fim/twilight/token"
 )
 
@@ -10,10 +11,10 @@ type AST struct {
 	Tokens     []*token.Token
 	TokenIndex int
 
-	Source string
+	Source *luna.SourceFile
 }
 
-func NewAST(tokens []*token.Token, source string) *AST {
+func NewAST(tokens []*token.Token, source *luna.SourceFile) *AST {
 	return &AST{
 		Tokens:     tokens,
 		TokenIndex: 0,
@@ -69,7 +70,7 @@ func (a *AST) EndOfFile() bool {
 }
 
 func (a *AST) GetSourceText(start int, length int) string {
-	return a.Source[start : start+length]
+	return a.Source.Content[start : start+length]
 }
 
 func (a *AST) CheckType(tokenTypes ...token.TokenType) bool {
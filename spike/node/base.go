@@ -1,6 +1,9 @@
 package node
 
-import "git.jaezmien.com/Jaezmien/fim/luna/errors"
+import (
+	"git.jaezmien.com/Jaezmien/fim/luna/errors"
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
 
 type Node struct {
 	Start  int
@@ -11,8 +14,13 @@ func (n Node) ToNode() Node {
 	return *NewNode(n.Start, n.Length)
 }
 
-func (n Node) CreateError(msg string, source string) error {
-	return errors.NewParseError(msg, source, n.Start)
+func (n Node) CreateError(msg string, file *luna.SourceFile) error {
+	return errors.NewParseError(msg, file, n.Start)
+}
+
+// Returns the start and end positions of the node.
+func (n Node) Range(file *luna.SourceFile) luna.Range {
+	return file.Range(n.Start, n.Length)
 }
 
 type DynamicNode interface {
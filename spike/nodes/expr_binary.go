@@ -35,6 +35,37 @@ const (
 	BINARYOPERATOR_EQ
 )
 
+var binaryTypeFriendlyName = map[BinaryExpressionType]string{
+	BINARYTYPE_UNKNOWN:    "UNKNOWN",
+	BINARYTYPE_ARITHMETIC: "ARITHMETIC",
+	BINARYTYPE_RELATIONAL: "RELATIONAL",
+}
+
+func (t BinaryExpressionType) String() string {
+	return binaryTypeFriendlyName[t]
+}
+
+var binaryOperatorFriendlyName = map[BinaryExpressionOperator]string{
+	BINARYOPERATOR_UNKNOWN: "UNKNOWN",
+	BINARYOPERATOR_ADD:     "ADD",
+	BINARYOPERATOR_SUB:     "SUB",
+	BINARYOPERATOR_MUL:     "MUL",
+	BINARYOPERATOR_DIV:     "DIV",
+	BINARYOPERATOR_MOD:     "MOD",
+	BINARYOPERATOR_AND:     "AND",
+	BINARYOPERATOR_OR:      "OR",
+	BINARYOPERATOR_GTE:     "GTE",
+	BINARYOPERATOR_LTE:     "LTE",
+	BINARYOPERATOR_GT:      "GT",
+	BINARYOPERATOR_LT:      "LT",
+	BINARYOPERATOR_NEQ:     "NEQ",
+	BINARYOPERATOR_EQ:      "EQ",
+}
+
+func (o BinaryExpressionOperator) String() string {
+	return binaryOperatorFriendlyName[o]
+}
+
 type BinaryExpressionNode struct {
 	Node
 
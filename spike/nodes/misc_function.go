@@ -3,6 +3,7 @@ package nodes
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"git.jaezmien.com/Jaezmien/fim/spike/ast"
 	"git.jaezmien.com/Jaezmien/fim/spike/variable"
@@ -28,6 +29,14 @@ type FunctionNodeParameter struct {
 	VariableType variable.VariableType
 }
 
+// Paragraphs with this prefix are run as test cases.
+const TestParagraphPrefix = "how to test"
+
+// Checks if the paragraph should be run as a test case.
+func (f *FunctionNode) IsTest() bool {
+	return strings.HasPrefix(strings.ToLower(f.Name), TestParagraphPrefix)
+}
+
 func ParseFunctionNode(ast *ast.AST) (*FunctionNode, error) {
 	function := &FunctionNode{
 		Main:       false,
@@ -73,7 +73,7 @@ func ParseReportNode(ast *ast.AST) (*ReportNode, error) {
 			continue
 		}
 
-		return nil, ast.Peek().CreateError(ast.Peek().Type.Message("Unxpected token: %s"), ast.Source)
+		return nil, ast.Peek().CreateError(ast.Peek().Type.Message("Unxpected token: %s")+KeywordHint(ast.Peek()), ast.Source)
 	}
 
 	_, err = ast.ConsumeToken(token.TokenType_ReportFooter, token.TokenType_ReportFooter.Message("Expected %s"))
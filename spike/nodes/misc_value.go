@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
 	"git.jaezmien.com/Jaezmien/fim/spike/ast"
 	"git.jaezmien.com/Jaezmien/fim/spike/variable"
 	"git.jaezmien.com/Jaezmien/fim/twilight/token"
@@ -31,7 +32,7 @@ func wrapAsDictionaryNode(n DynamicNode, arrayType variable.VariableType, start
 }
 
 func CreateValueNode(tokens []*token.Token, options CreateValueNodeOptions) (DynamicNode, error) {
-	tempAST := ast.NewAST(tokens, "")
+	tempAST := ast.NewAST(tokens, luna.NewSourceFile(""))
 
 	if tempAST.Length() == 0 && options.possibleNullType != nil {
 		if options.possibleNullType != nil && options.possibleNullType.IsArray() {
@@ -64,6 +65,14 @@ func CreateValueNode(tokens []*token.Token, options CreateValueNodeOptions) (Dyn
 			), nil
 		}
 
+		if tempAST.CheckType(token.TokenType_MoreInput) {
+			t := tempAST.Consume()
+
+			return &MoreInputNode{
+				Node: *NewNode(t.Start, t.Length),
+			}, nil
+		}
+
 		if tempAST.CheckType(token.TokenType_Identifier) {
 			t := tempAST.Consume()
 
@@ -0,0 +1,39 @@
+package nodes
+
+import (
+	"git.jaezmien.com/Jaezmien/fim/spike/ast"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	. "git.jaezmien.com/Jaezmien/fim/spike/node"
+)
+
+// Reads an environment variable into a STRING variable.
+//
+// I fetched Home from "HOME".
+type EnvironmentReadNode struct {
+	Node
+
+	Identifier string
+	Name       DynamicNode
+}
+
+func ParseEnvironmentReadNode(ast *ast.AST) (*EnvironmentReadNode, error) {
+	node := &EnvironmentReadNode{}
+
+	startToken, err := ast.ConsumeToken(token.TokenType_EnvironmentRead, token.TokenType_EnvironmentRead.Message("Expected %s"))
+	if err != nil {
+		return nil, err
+	}
+
+	identifier, name, endToken, err := parseReadSource(ast)
+	if err != nil {
+		return nil, err
+	}
+	node.Identifier = identifier
+	node.Name = name
+
+	node.Start = startToken.Start
+	node.Length = endToken.Start + endToken.Length - startToken.Start
+
+	return node, nil
+}
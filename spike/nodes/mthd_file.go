@@ -0,0 +1,166 @@
+package nodes
+
+import (
+	"git.jaezmien.com/Jaezmien/fim/spike/ast"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	. "git.jaezmien.com/Jaezmien/fim/spike/node"
+)
+
+// Reads the whole file into a STRING variable.
+//
+// I studied Story from "story.txt".
+type FileReadNode struct {
+	Node
+
+	Identifier string
+	Path       DynamicNode
+}
+
+// Reads every line of the file into a STRING_ARRAY variable.
+//
+// I studied every line of Lines from "story.txt".
+type FileReadLinesNode struct {
+	Node
+
+	Identifier string
+	Path       DynamicNode
+}
+
+// Writes, or appends a STRING to the file.
+//
+// I penned Story in "story.txt".
+// I appended Story to "story.txt".
+type FileWriteNode struct {
+	Node
+
+	Append bool
+	Value  DynamicNode
+	Path   DynamicNode
+}
+
+// Parses '<identifier> from <value>.'
+func parseReadSource(ast *ast.AST) (string, DynamicNode, *token.Token, error) {
+	identifier, err := ast.ConsumeToken(token.TokenType_Identifier, token.TokenType_Identifier.Message("Expected %s"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	_, err = ast.ConsumeToken(token.TokenType_KeywordFrom, token.TokenType_KeywordFrom.Message("Expected %s"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	pathTokens, err := ConsumeUntilPunctuation(ast, false)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	path, err := CreateValueNode(pathTokens, CreateValueNodeOptions{})
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	endToken, err := ast.ConsumeToken(token.TokenType_Punctuation, token.TokenType_Punctuation.Message("Expected %s"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return identifier.Value, path, endToken, nil
+}
+
+func ParseFileReadNode(ast *ast.AST) (*FileReadNode, error) {
+	node := &FileReadNode{}
+
+	startToken, err := ast.ConsumeToken(token.TokenType_FileRead, token.TokenType_FileRead.Message("Expected %s"))
+	if err != nil {
+		return nil, err
+	}
+
+	identifier, path, endToken, err := parseReadSource(ast)
+	if err != nil {
+		return nil, err
+	}
+	node.Identifier = identifier
+	node.Path = path
+
+	node.Start = startToken.Start
+	node.Length = endToken.Start + endToken.Length - startToken.Start
+
+	return node, nil
+}
+
+func ParseFileReadLinesNode(ast *ast.AST) (*FileReadLinesNode, error) {
+	node := &FileReadLinesNode{}
+
+	startToken, err := ast.ConsumeToken(token.TokenType_FileReadLines, token.TokenType_FileReadLines.Message("Expected %s"))
+	if err != nil {
+		return nil, err
+	}
+
+	identifier, path, endToken, err := parseReadSource(ast)
+	if err != nil {
+		return nil, err
+	}
+	node.Identifier = identifier
+	node.Path = path
+
+	node.Start = startToken.Start
+	node.Length = endToken.Start + endToken.Length - startToken.Start
+
+	return node, nil
+}
+
+func ParseFileWriteNode(ast *ast.AST) (*FileWriteNode, error) {
+	node := &FileWriteNode{}
+
+	startToken, err := ast.ConsumeFunc(func(t *token.Token) bool {
+		return t.Type == token.TokenType_FileWrite || t.Type == token.TokenType_FileAppend
+	}, "Expected file write token")
+	if err != nil {
+		return nil, err
+	}
+	node.Append = startToken.Type == token.TokenType_FileAppend
+
+	separator := token.TokenType_KeywordIn
+	if node.Append {
+		separator = token.TokenType_KeywordTo
+	}
+
+	valueTokens, err := ast.ConsumeUntilFuncMatch(func(t *token.Token) bool {
+		return t.Type == separator || t.Type == token.TokenType_Punctuation
+	}, separator.Message("Could not find %s"))
+	if err != nil {
+		return nil, err
+	}
+	if len(valueTokens) == 0 {
+		return nil, startToken.CreateError("Expected a value to write", ast.Source)
+	}
+	node.Value, err = CreateValueNode(valueTokens, CreateValueNodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ast.ConsumeToken(separator, separator.Message("Expected %s"))
+	if err != nil {
+		return nil, err
+	}
+
+	pathTokens, err := ConsumeUntilPunctuation(ast, false)
+	if err != nil {
+		return nil, err
+	}
+	node.Path, err = CreateValueNode(pathTokens, CreateValueNodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	endToken, err := ast.ConsumeToken(token.TokenType_Punctuation, token.TokenType_Punctuation.Message("Expected %s"))
+	if err != nil {
+		return nil, err
+	}
+
+	node.Start = startToken.Start
+	node.Length = endToken.Start + endToken.Length - startToken.Start
+
+	return node, nil
+}
@@ -91,3 +91,9 @@ func ParsePromptNode(ast *ast.AST) (*PromptNode, error) {
 
 	return node, nil
 }
+
+// A MoreInputNode is a BOOLEAN value that is true until a prompt has reached
+// the end of its input.
+type MoreInputNode struct {
+	Node
+}
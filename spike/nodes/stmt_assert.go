@@ -0,0 +1,43 @@
+package nodes
+
+import (
+	"git.jaezmien.com/Jaezmien/fim/spike/ast"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	. "git.jaezmien.com/Jaezmien/fim/spike/node"
+)
+
+type AssertNode struct {
+	Node
+
+	Condition DynamicNode
+}
+
+func ParseAssertNode(ast *ast.AST) (*AssertNode, error) {
+	node := &AssertNode{}
+
+	startToken, err := ast.ConsumeToken(token.TokenType_Assert, token.TokenType_Assert.Message("Expected %s"))
+	if err != nil {
+		return nil, err
+	}
+
+	conditionTokens, err := ConsumeUntilPunctuation(ast, false)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Condition, err = CreateValueNode(conditionTokens, CreateValueNodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	endToken, err := ast.ConsumeToken(token.TokenType_Punctuation, token.TokenType_Punctuation.Message("Expected %s"))
+	if err != nil {
+		return nil, err
+	}
+
+	node.Start = startToken.Start
+	node.Length = endToken.Start + endToken.Length - startToken.Start
+
+	return node, nil
+}
@@ -38,6 +38,38 @@ func ParseStatementsNode(curAST *ast.AST, expectedEndType ...token.TokenType) (*
 				return ParsePromptNode(ast)
 			},
 		},
+		{
+			Check: func() bool {
+				return curAST.CheckType(token.TokenType_FileRead)
+			},
+			Parser: func(ast *ast.AST) (DynamicNode, error) {
+				return ParseFileReadNode(ast)
+			},
+		},
+		{
+			Check: func() bool {
+				return curAST.CheckType(token.TokenType_FileReadLines)
+			},
+			Parser: func(ast *ast.AST) (DynamicNode, error) {
+				return ParseFileReadLinesNode(ast)
+			},
+		},
+		{
+			Check: func() bool {
+				return curAST.CheckType(token.TokenType_FileWrite) || curAST.CheckType(token.TokenType_FileAppend)
+			},
+			Parser: func(ast *ast.AST) (DynamicNode, error) {
+				return ParseFileWriteNode(ast)
+			},
+		},
+		{
+			Check: func() bool {
+				return curAST.CheckType(token.TokenType_EnvironmentRead)
+			},
+			Parser: func(ast *ast.AST) (DynamicNode, error) {
+				return ParseEnvironmentReadNode(ast)
+			},
+		},
 		{
 			Check: func() bool {
 				return curAST.CheckType(token.TokenType_Declaration)
@@ -86,6 +118,14 @@ func ParseStatementsNode(curAST *ast.AST, expectedEndType ...token.TokenType) (*
 				return ParseFunctionReturnNode(ast)
 			},
 		},
+		{
+			Check: func() bool {
+				return curAST.CheckType(token.TokenType_Assert)
+			},
+			Parser: func(ast *ast.AST) (DynamicNode, error) {
+				return ParseAssertNode(ast)
+			},
+		},
 		{
 			Check: func() bool {
 				return curAST.CheckType(token.TokenType_ForEveryClause) &&
@@ -169,7 +209,7 @@ func ParseStatementsNode(curAST *ast.AST, expectedEndType ...token.TokenType) (*
 			continue
 		}
 
-		return nil, curAST.Peek().CreateError(fmt.Sprintf("Unsupported statement token: %s", curAST.Peek().Type), curAST.Source)
+		return nil, curAST.Peek().CreateError(fmt.Sprintf("Unsupported statement token: %s%s", curAST.Peek().Type, KeywordHint(curAST.Peek())), curAST.Source)
 	}
 
 	return statements, nil
@@ -1,7 +1,10 @@
 package nodes
 
 import (
+	"fmt"
+
 	"git.jaezmien.com/Jaezmien/fim/spike/ast"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
 	"git.jaezmien.com/Jaezmien/fim/twilight/token"
 )
 
@@ -23,3 +26,18 @@ func ConsumeUntilPunctuation(ast *ast.AST, isArray bool) (valueTokens []*token.T
 
 	return valueTokens, err
 }
+
+// Returns a ". Did you mean ...?" hint if the identifier token looks like a mistyped
+// keyword, or an empty string otherwise.
+func KeywordHint(t *token.Token) string {
+	if t.Type != token.TokenType_Identifier {
+		return ""
+	}
+
+	suggestion, ok := twilight.SuggestKeyword(t.Value)
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf(". Did you mean '%s'?", suggestion)
+}
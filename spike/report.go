@@ -1,6 +1,7 @@
 package spike
 
 import (
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
 	"git.jaezmien.com/Jaezmien/fim/spike/ast"
 	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
 	"git.jaezmien.com/Jaezmien/fim/twilight/token"
@@ -11,7 +12,7 @@ func CreateReport(tokens []*token.Token, source string) (*nodes.ReportNode, erro
 	ast := &ast.AST{
 		Tokens:     tokens,
 		TokenIndex: 0,
-		Source:     source,
+		Source:     luna.NewSourceFile(source),
 	}
 
 	return nodes.ParseReportNode(ast)
@@ -0,0 +1,126 @@
+package spike
+
+import (
+	"fmt"
+	"reflect"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/node"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+var (
+	nodeType            = reflect.TypeOf(node.Node{})
+	dynamicVariableType = reflect.TypeOf(&variable.DynamicVariable{})
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// Convert a node into plain values (maps, slices, strings, numbers, and booleans)
+// that can be encoded as JSON.
+//
+// A node becomes an object with its Go type as "Type", its position as "Start" and
+// "Length", and its exported fields under their Go names.
+func Serialize(n any) any {
+	return serializeValue(reflect.ValueOf(n))
+}
+
+func serializeValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type() == dynamicVariableType {
+		if v.IsNil() {
+			return nil
+		}
+
+		dynamicVariable := v.Interface().(*variable.DynamicVariable)
+		value := map[string]any{
+			"VariableType": dynamicVariable.GetType().String(),
+		}
+		if !dynamicVariable.GetType().IsArray() {
+			value["Value"] = dynamicVariable.GetValueString()
+		}
+		return value
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return serializeValue(v.Elem())
+	case reflect.Struct:
+		object := make(map[string]any)
+		if v.Type().Name() != "" {
+			object["Type"] = v.Type().String()
+		}
+		serializeFields(v, object)
+		return object
+	case reflect.Slice, reflect.Array:
+		values := make([]any, 0, v.Len())
+		for idx := range v.Len() {
+			values = append(values, serializeValue(v.Index(idx)))
+		}
+		return values
+	case reflect.Map:
+		values := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			values[fmt.Sprint(iter.Key().Interface())] = serializeValue(iter.Value())
+		}
+		return values
+	}
+
+	if v.Type().Implements(stringerType) {
+		return v.Interface().(fmt.Stringer).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	default:
+		return nil
+	}
+}
+
+// Copy the exported fields of a struct into the object.
+// Embedded structs have their fields flattened into the same object.
+func serializeFields(v reflect.Value, object map[string]any) {
+	for idx := range v.NumField() {
+		field := v.Type().Field(idx)
+		value := v.Field(idx)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Type == nodeType {
+			// Only the outermost node determines the position
+			if _, ok := object["Start"]; !ok {
+				object["Start"] = value.Interface().(node.Node).Start
+				object["Length"] = value.Interface().(node.Node).Length
+			}
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			serializeFields(value, object)
+			continue
+		}
+
+		if field.Anonymous && field.Type == dynamicVariableType {
+			object["Value"] = serializeValue(value)
+			continue
+		}
+
+		object[field.Name] = serializeValue(value)
+	}
+}
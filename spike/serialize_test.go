@@ -0,0 +1,48 @@
+package spike
+
+import (
+	"encoding/json"
+	"testing"
+
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialize(t *testing.T) {
+	source := `Dear Princess Celestia: Serializing!
+Today I learned how to serialize!
+	I said 1 plus 2!
+That's all about how to serialize.
+Your faithful student, Spike.`
+
+	report, err := CreateReport(twilight.Parse(source), source)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	serialized, ok := Serialize(report).(map[string]any)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, "nodes.ReportNode", serialized["Type"])
+	assert.Equal(t, "Serializing", serialized["Title"])
+	assert.Equal(t, "Spike", serialized["Author"])
+
+	paragraph := serialized["Body"].([]any)[0].(map[string]any)
+	assert.Equal(t, "nodes.FunctionNode", paragraph["Type"])
+	assert.Equal(t, true, paragraph["Main"])
+	assert.Equal(t, "", paragraph["ReturnType"])
+
+	print := paragraph["Body"].(map[string]any)["Statements"].([]any)[0].(map[string]any)
+	assert.Equal(t, "nodes.PrintNode", print["Type"])
+	assert.Equal(t, 72, print["Start"])
+	assert.Equal(t, 16, print["Length"])
+
+	value := print["Value"].(map[string]any)
+	assert.Equal(t, "nodes.BinaryExpressionNode", value["Type"])
+	assert.Equal(t, "ADD", value["Operator"])
+	assert.Equal(t, map[string]any{"VariableType": "NUMBER", "Value": "1"}, value["Left"].(map[string]any)["Value"])
+
+	_, err = json.Marshal(serialized)
+	assert.NoError(t, err)
+}
@@ -36,6 +36,17 @@ func (t VariableType) String() string {
 	return variableTypeFriendlyName[t]
 }
 
+// Returns the variable type with the given friendly name
+func ParseVariableType(name string) (VariableType, bool) {
+	for t, friendlyName := range variableTypeFriendlyName {
+		if t != UNKNOWN && friendlyName == name {
+			return t, true
+		}
+	}
+
+	return UNKNOWN, false
+}
+
 func (t VariableType) IsArray() bool {
 	switch t {
 	case BOOLEAN_ARRAY:
@@ -28,7 +28,7 @@ func createTokens(partialTokens *queue.Queue[*token.Token]) *queue.Queue[*token.
 			return t.Length == 1 && slices.Contains(punctuations[:], rune(t.Value[0]))
 		}, result: token.TokenType_Punctuation},
 		{condition: func(t *token.Token) bool { return t.Length == 1 && t.Value == "\n" }, result: token.TokenType_NewLine},
-		{condition: func(t *token.Token) bool { return t.Length == 1 && t.Value == " " }, result: token.TokenType_Whitespace},
+		{condition: func(t *token.Token) bool { return utilities.IsIndentString(t.Value) }, result: token.TokenType_Whitespace},
 		{condition: func(t *token.Token) bool {
 			return t.Length >= 1 && strings.HasPrefix(t.Value, "(") && strings.HasSuffix(t.Value, ")")
 		}, result: token.TokenType_CommentParen},
@@ -72,7 +72,7 @@ func createTokens(partialTokens *queue.Queue[*token.Token]) *queue.Queue[*token.
 	lastToken := tokens.Last()
 	startIndex := 0
 	if lastToken != nil {
-		startIndex = lastToken.Value.Start
+		startIndex = lastToken.Value.Start + lastToken.Value.Length
 	}
 
 	tokens.Queue(&token.Token{
@@ -89,78 +89,20 @@ func createTokens(partialTokens *queue.Queue[*token.Token]) *queue.Queue[*token.
 func mergeMultiTokens(oldTokens *queue.Queue[*token.Token]) *queue.Queue[*token.Token] {
 	tokens := queue.New[*token.Token]()
 
-	multiTokenProcessors := []struct {
+	type multiTokenProcessor struct {
 		condition func(tokens *queue.Queue[*token.Token]) int
 		result    token.TokenType
-	}{
-		{condition: parsers.CheckReportHeader, result: token.TokenType_ReportHeader},
-		{condition: parsers.CheckReportFooter, result: token.TokenType_ReportFooter},
-
-		{condition: parsers.CheckFunctionHeaderMain, result: token.TokenType_FunctionMain},
-		{condition: parsers.CheckFunctionHeader, result: token.TokenType_FunctionHeader},
-		{condition: parsers.CheckFunctionFooter, result: token.TokenType_FunctionFooter},
-		{condition: parsers.CheckFunctionParameter, result: token.TokenType_FunctionParameter},
-		{condition: parsers.CheckFunctionReturn, result: token.TokenType_FunctionReturn},
-
-		{condition: parsers.CheckPrintMethod, result: token.TokenType_Print},
-		{condition: parsers.CheckPrintNewlineMethod, result: token.TokenType_PrintNewline},
-		{condition: parsers.CheckReadMethod, result: token.TokenType_Prompt},
-		{condition: parsers.CheckFunctionCallMethod, result: token.TokenType_FunctionCall},
-
-		{condition: parsers.CheckVariableDeclaration, result: token.TokenType_Declaration},
-		{condition: parsers.CheckVariableModifier, result: token.TokenType_Modify},
-
-		{condition: parsers.CheckBooleanType, result: token.TokenType_TypeBoolean},
-		{condition: parsers.CheckBooleanArrayType, result: token.TokenType_TypeBooleanArray},
-		{condition: parsers.CheckNumberType, result: token.TokenType_TypeNumber},
-		{condition: parsers.CheckNumberArrayType, result: token.TokenType_TypeNumberArray},
-		{condition: parsers.CheckStringType, result: token.TokenType_TypeString},
-		{condition: parsers.CheckStringArrayType, result: token.TokenType_TypeStringArray},
-		{condition: parsers.CheckCharacterType, result: token.TokenType_TypeChar},
+	}
 
+	// A postscript starts with 'P', which does not start any keyword phrase
+	multiTokenProcessors := []multiTokenProcessor{
 		{condition: parsers.CheckPostscript, result: token.TokenType_CommentPostScript},
-
-		{condition: parsers.CheckIfKeyword, result: token.TokenType_IfClause},
-		{condition: parsers.CheckElseKeyword, result: token.TokenType_ElseClause},
-		{condition: parsers.CheckIfEndKeyword, result: token.TokenType_IfEndClause},
-
-		{condition: parsers.CheckWhileKeyword, result: token.TokenType_WhileClause},
-		{condition: parsers.CheckForEveryKeyword, result: token.TokenType_ForEveryClause},
-		{condition: parsers.CheckStatementEndKeyword, result: token.TokenType_KeywordStatementEnd},
-
-		{condition: parsers.CheckInfixAddition, result: token.TokenType_OperatorAddInfix},
-		{condition: parsers.CheckPrefixAddition, result: token.TokenType_OperatorAddPrefix},
-		{condition: parsers.CheckInfixSubtraction, result: token.TokenType_OperatorSubInfix},
-		{condition: parsers.CheckPrefixSubtraction, result: token.TokenType_OperatorSubPrefix},
-		{condition: parsers.CheckInfixMultiplication, result: token.TokenType_OperatorMulInfix},
-		{condition: parsers.CheckPrefixMultiplication, result: token.TokenType_OperatorMulPrefix},
-		{condition: parsers.CheckInfixDivision, result: token.TokenType_OperatorDivInfix},
-		{condition: parsers.CheckPrefixDivision, result: token.TokenType_OperatorDivPrefix},
-
-		{condition: parsers.CheckPrefixModulo, result: token.TokenType_OperatorModPrefix},
-		{condition: parsers.CheckInfixModulo, result: token.TokenType_OperatorModInfix},
-
-		{condition: parsers.CheckLessThanEqualOperator, result: token.TokenType_OperatorLte},
-		{condition: parsers.CheckGreaterThanEqualOperator, result: token.TokenType_OperatorGte},
-		{condition: parsers.CheckGreaterThanOperator, result: token.TokenType_OperatorGt},
-		{condition: parsers.CheckLessThanOperator, result: token.TokenType_OperatorLt},
-		{condition: parsers.CheckNotEqualOperator, result: token.TokenType_OperatorNeq},
-		{condition: parsers.CheckEqualOperator, result: token.TokenType_OperatorEq},
-
-		{condition: parsers.CheckUnaryPrefixIncrement, result: token.TokenType_UnaryIncrementPrefix},
-		{condition: parsers.CheckUnaryPostfixIncrement, result: token.TokenType_UnaryIncrementPostfix},
-		{condition: parsers.CheckUnaryPrefixDecrement, result: token.TokenType_UnaryDecrementPrefix},
-		{condition: parsers.CheckUnaryPostfixDecrement, result: token.TokenType_UnaryDecrementPostfix},
-
-		{condition: parsers.CheckReturnKeyword, result: token.TokenType_KeywordReturn},
-		{condition: parsers.CheckConstantKeyword, result: token.TokenType_KeywordConst},
-		{condition: parsers.CheckThenKeyword, result: token.TokenType_KeywordThen},
-		{condition: parsers.CheckAndKeyword, result: token.TokenType_KeywordAnd},
-		{condition: parsers.CheckOrKeyword, result: token.TokenType_KeywordOr},
-		{condition: parsers.CheckOfKeyword, result: token.TokenType_KeywordOf},
-		{condition: parsers.CheckInKeyword, result: token.TokenType_KeywordIn},
-		{condition: parsers.CheckFromKeyword, result: token.TokenType_KeywordFrom},
-		{condition: parsers.CheckToKeyword, result: token.TokenType_KeywordTo},
+	}
+	for _, keyword := range parsers.Keywords {
+		multiTokenProcessors = append(multiTokenProcessors, multiTokenProcessor{
+			condition: func(tokens *queue.Queue[*token.Token]) int { return parsers.CheckKeyword(tokens, keyword) },
+			result:    keyword.Type,
+		})
 	}
 
 	for oldTokens.Len() > 0 {
@@ -200,7 +142,8 @@ func smartIdentifierTokens(oldTokens *queue.Queue[*token.Token]) *queue.Queue[*t
 	for oldTokens.Len() > 0 {
 		t := oldTokens.Dequeue().Value
 
-		if t.Type == token.TokenType_ForEveryClause {
+		// File and environment statements use the same keywords as for-every statements
+		if t.Type == token.TokenType_ForEveryClause || t.Type.IsFileMethod() || t.Type == token.TokenType_EnvironmentRead {
 			isForEvery = true
 		}
 
@@ -268,6 +211,51 @@ func mergeIdentifiers(oldTokens *queue.Queue[*token.Token]) *queue.Queue[*token.
 	return tokens
 }
 
+// Merge the remaining text of a line into its postscript token, so that the
+// comment is kept as a single token.
+func mergePostscripts(oldTokens *queue.Queue[*token.Token]) *queue.Queue[*token.Token] {
+	tokens := queue.New[*token.Token]()
+
+	for oldTokens.Len() > 0 {
+		t := oldTokens.Dequeue().Value
+
+		if t.Type == token.TokenType_CommentPostScript {
+			for oldTokens.Len() > 0 &&
+				(oldTokens.First().Value.Type != token.TokenType_NewLine &&
+					oldTokens.First().Value.Type != token.TokenType_EndOfFile) {
+				t.Append(oldTokens.Dequeue().Value)
+			}
+		}
+
+		tokens.Queue(t)
+	}
+
+	return tokens
+}
+
+// Merge the first line of the report into a shebang token, so that a report
+// can be run as an executable script.
+//
+// Note: This should only be called if the source starts with '#!'
+func mergeShebang(oldTokens *queue.Queue[*token.Token]) *queue.Queue[*token.Token] {
+	tokens := queue.New[*token.Token]()
+
+	shebang := oldTokens.Dequeue().Value
+	shebang.Type = token.TokenType_CommentShebang
+	for oldTokens.Len() > 0 &&
+		(oldTokens.First().Value.Type != token.TokenType_NewLine &&
+			oldTokens.First().Value.Type != token.TokenType_EndOfFile) {
+		shebang.Append(oldTokens.Dequeue().Value)
+	}
+	tokens.Queue(shebang)
+
+	for oldTokens.Len() > 0 {
+		tokens.Queue(oldTokens.Dequeue().Value)
+	}
+
+	return tokens
+}
+
 // Remove any unnecessary tokens from the token queue
 func cleanTokens(oldTokens *queue.Queue[*token.Token]) *queue.Queue[*token.Token] {
 	tokens := queue.New[*token.Token]()
@@ -285,11 +273,9 @@ func cleanTokens(oldTokens *queue.Queue[*token.Token]) *queue.Queue[*token.Token
 			continue
 		}
 		if t.Type == token.TokenType_CommentPostScript {
-			for oldTokens.Len() > 0 &&
-				(oldTokens.First().Value.Type != token.TokenType_NewLine &&
-					oldTokens.First().Value.Type != token.TokenType_EndOfFile) {
-				oldTokens.Dequeue()
-			}
+			continue
+		}
+		if t.Type == token.TokenType_CommentShebang {
 			continue
 		}
 
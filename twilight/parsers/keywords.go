@@ -0,0 +1,378 @@
+package parsers
+
+import (
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/luna/queue"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+	"git.jaezmien.com/Jaezmien/fim/twilight/utilities"
+)
+
+// A Keyword is a token type, and every phrase that is lexed into it.
+//
+// Each phrase is written as the partial tokens that it is made of,
+// e.g.: "That's all about" is {"That", "'", "s", " ", "all", " ", "about"}
+type Keyword struct {
+	Type    token.TokenType
+	Phrases [][]string
+}
+
+// Every keyword, in the order that the lexer checks them.
+//
+// The first keyword with a phrase that matches is used, so a keyword must
+// come before any other keyword that shares the start of its phrases.
+// The phrases of a keyword are checked in order as well.
+var Keywords = []Keyword{
+	{Type: token.TokenType_ReportHeader, Phrases: [][]string{
+		{"Dear", " ", "Princess", " ", "Celestia", ":"},
+	}},
+	{Type: token.TokenType_ReportFooter, Phrases: [][]string{
+		{"Your", " ", "faithful", " ", "student", ","},
+	}},
+
+	{Type: token.TokenType_FunctionMain, Phrases: [][]string{
+		{"Today", " ", "I", " ", "learned"},
+	}},
+	{Type: token.TokenType_FunctionHeader, Phrases: [][]string{
+		{"I", " ", "learned"},
+	}},
+	{Type: token.TokenType_FunctionFooter, Phrases: [][]string{
+		{"That", "'", "s", " ", "all", " ", "about"},
+	}},
+	{Type: token.TokenType_FunctionParameter, Phrases: [][]string{
+		{"using"},
+	}},
+	{Type: token.TokenType_FunctionReturn, Phrases: [][]string{
+		{"with"},
+		{"to", " ", "get"},
+	}},
+
+	{Type: token.TokenType_Print, Phrases: [][]string{
+		{"I", " ", "quickly", " ", "said"},
+		{"I", " ", "quickly", " ", "sang"},
+		{"I", " ", "quickly", " ", "wrote"},
+	}},
+	{Type: token.TokenType_PrintNewline, Phrases: [][]string{
+		{"I", " ", "said"},
+		{"I", " ", "sang"},
+		{"I", " ", "wrote"},
+	}},
+	{Type: token.TokenType_Prompt, Phrases: [][]string{
+		{"I", " ", "heard"},
+		{"I", " ", "read"},
+		{"I", " ", "asked"},
+	}},
+	{Type: token.TokenType_MoreInput, Phrases: [][]string{
+		{"there", " ", "is", " ", "more", " ", "to", " ", "read"},
+		{"There", " ", "is", " ", "more", " ", "to", " ", "read"},
+	}},
+	// I studied every line of Lines from "story.txt".
+	{Type: token.TokenType_FileReadLines, Phrases: [][]string{
+		{"I", " ", "studied", " ", "every", " ", "line", " ", "of"},
+	}},
+	// I studied Story from "story.txt".
+	{Type: token.TokenType_FileRead, Phrases: [][]string{
+		{"I", " ", "studied"},
+	}},
+	// I penned Story in "story.txt".
+	{Type: token.TokenType_FileWrite, Phrases: [][]string{
+		{"I", " ", "penned"},
+	}},
+	// I appended Story to "story.txt".
+	{Type: token.TokenType_FileAppend, Phrases: [][]string{
+		{"I", " ", "appended"},
+	}},
+	// I fetched Home from "HOME".
+	{Type: token.TokenType_EnvironmentRead, Phrases: [][]string{
+		{"I", " ", "fetched"},
+	}},
+	{Type: token.TokenType_FunctionCall, Phrases: [][]string{
+		{"I", " ", "remembered"},
+		{"I", " ", "would"},
+	}},
+
+	{Type: token.TokenType_Declaration, Phrases: [][]string{
+		{"Did", " ", "you", " ", "know", " ", "that"},
+	}},
+	{Type: token.TokenType_Modify, Phrases: [][]string{
+		{"becomes"},
+		{"become"},
+		{"became"},
+		{"is", " ", "now"},
+	}},
+
+	{Type: token.TokenType_TypeBoolean, Phrases: [][]string{
+		{"argument"},
+		{"logic"},
+		{"an", " ", "argument"},
+		{"the", " ", "argument"},
+		{"the", " ", "logic"},
+	}},
+	{Type: token.TokenType_TypeBooleanArray, Phrases: [][]string{
+		{"arguments"},
+		{"logics"},
+		{"many", " ", "arguments"},
+		{"many", " ", "logics"},
+		{"the", " ", "arguments"},
+		{"the", " ", "logics"},
+	}},
+	{Type: token.TokenType_TypeNumber, Phrases: [][]string{
+		{"number"},
+		{"a", " ", "number"},
+		{"the", " ", "number"},
+	}},
+	{Type: token.TokenType_TypeNumberArray, Phrases: [][]string{
+		{"numbers"},
+		{"the", " ", "numbers"},
+		{"many", " ", "numbers"},
+	}},
+	{Type: token.TokenType_TypeString, Phrases: [][]string{
+		{"characters"},
+		{"letters"},
+		{"phrase"},
+		{"quote"},
+		{"sentence"},
+		{"word"},
+		{"a", " ", "phrase"},
+		{"a", " ", "quote"},
+		{"a", " ", "sentence"},
+		{"a", " ", "word"},
+		{"the", " ", "characters"},
+		{"the", " ", "letters"},
+		{"the", " ", "phrase"},
+		{"the", " ", "quote"},
+		{"the", " ", "sentence"},
+		{"the", " ", "word"},
+	}},
+	{Type: token.TokenType_TypeStringArray, Phrases: [][]string{
+		{"phrases"},
+		{"quotes"},
+		{"sentences"},
+		{"words"},
+		{"many", " ", "phrases"},
+		{"many", " ", "quotes"},
+		{"many", " ", "sentences"},
+		{"many", " ", "words"},
+		{"the", " ", "phrases"},
+		{"the", " ", "quotes"},
+		{"the", " ", "sentences"},
+		{"the", " ", "words"},
+	}},
+	{Type: token.TokenType_TypeChar, Phrases: [][]string{
+		{"character"},
+		{"letter"},
+		{"a", " ", "character"},
+		{"a", " ", "letter"},
+		{"the", " ", "character"},
+		{"the", " ", "letter"},
+	}},
+
+	{Type: token.TokenType_IfClause, Phrases: [][]string{
+		{"If"},
+		{"When"},
+	}},
+	// 'Otherwise if' and 'Or else if' are else clauses as well,
+	// so that they are not lexed as the start of an if clause
+	{Type: token.TokenType_ElseClause, Phrases: [][]string{
+		{"Otherwise", " ", "if"},
+		{"Or", " ", "else", " ", "if"},
+		{"Or", " ", "else"},
+		{"Otherwise"},
+	}},
+	{Type: token.TokenType_IfEndClause, Phrases: [][]string{
+		{"That", "'", "s", " ", "what", " ", "I", " ", "would", " ", "do"},
+	}},
+
+	{Type: token.TokenType_WhileClause, Phrases: [][]string{
+		{"As", " ", "long", " ", "as"},
+		{"Here", "'", "s", " ", "what", " ", "I", " ", "did", " ", "while"},
+	}},
+	{Type: token.TokenType_ForEveryClause, Phrases: [][]string{
+		{"For", " ", "every"},
+	}},
+	{Type: token.TokenType_KeywordStatementEnd, Phrases: [][]string{
+		{"That", "'", "s", " ", "what", " ", "I", " ", "did"},
+	}},
+	{Type: token.TokenType_Assert, Phrases: [][]string{
+		{"I", " ", "made", " ", "sure", " ", "that"},
+	}},
+
+	{Type: token.TokenType_OperatorAddInfix, Phrases: [][]string{
+		{"plus"},
+		{"added", " ", "to"},
+	}},
+	{Type: token.TokenType_OperatorAddPrefix, Phrases: [][]string{
+		{"add"},
+	}},
+	{Type: token.TokenType_OperatorSubInfix, Phrases: [][]string{
+		{"minus"},
+		{"without"},
+	}},
+	{Type: token.TokenType_OperatorSubPrefix, Phrases: [][]string{
+		{"subtract"},
+		{"the", " ", "difference", " ", "between"},
+	}},
+	{Type: token.TokenType_OperatorMulInfix, Phrases: [][]string{
+		{"times"},
+		{"multiplied", " ", "with"},
+	}},
+	{Type: token.TokenType_OperatorMulPrefix, Phrases: [][]string{
+		{"multiply"},
+	}},
+	{Type: token.TokenType_OperatorDivInfix, Phrases: [][]string{
+		{"divided", " ", "by"},
+	}},
+	{Type: token.TokenType_OperatorDivPrefix, Phrases: [][]string{
+		{"divide"},
+	}},
+
+	{Type: token.TokenType_OperatorModPrefix, Phrases: [][]string{
+		{"remainder", " ", "of"},
+	}},
+	{Type: token.TokenType_OperatorModInfix, Phrases: [][]string{
+		{"mod"},
+		{"modulo"},
+		{"remainder"},
+	}},
+
+	{Type: token.TokenType_OperatorLte, Phrases: [][]string{
+		{"had", " ", "no", " ", "more", " ", "than"},
+		{"has", " ", "no", " ", "more", " ", "than"},
+		{"is", " ", "no", " ", "greater", " ", "than"},
+		{"is", " ", "no", " ", "more", " ", "than"},
+		{"is", " ", "not", " ", "greater", " ", "than"},
+		{"is", " ", "not", " ", "more", " ", "than"},
+		{"isn", "'", "t", " ", "greater", " ", "than"},
+		{"isn", "'", "t", " ", "more", " ", "than"},
+		{"was", " ", "no", " ", "greater", " ", "than"},
+		{"was", " ", "no", " ", "more", " ", "than"},
+		{"was", " ", "not", " ", "greater", " ", "than"},
+		{"was", " ", "not", " ", "more", " ", "than"},
+		{"wasn", "'", "t", " ", "greater", " ", "than"},
+		{"wasn", "'", "t", " ", "more", " ", "than"},
+		{"were", " ", "no", " ", "greater", " ", "than"},
+		{"were", " ", "no", " ", "more", " ", "than"},
+		{"were", " ", "not", " ", "greater", " ", "than"},
+		{"were", " ", "not", " ", "more", " ", "than"},
+		{"weren", "'", "t", " ", "greater", " ", "than"},
+		{"weren", "'", "t", " ", "more", " ", "than"},
+	}},
+	{Type: token.TokenType_OperatorGte, Phrases: [][]string{
+		{"had", " ", "no", " ", "less", " ", "than"},
+		{"has", " ", "no", " ", "less", " ", "than"},
+		{"is", " ", "no", " ", "less", " ", "than"},
+		{"is", " ", "not", " ", "less", " ", "than"},
+		{"isn", "'", "t", " ", "less", " ", "than"},
+		{"was", " ", "no", " ", "less", " ", "than"},
+		{"was", " ", "not", " ", "less", " ", "than"},
+		{"wasn", "'", "t", " ", "less", " ", "than"},
+		{"were", " ", "no", " ", "less", " ", "than"},
+		{"were", " ", "not", " ", "less", " ", "than"},
+		{"weren", "'", "t", " ", "less", " ", "than"},
+	}},
+	{Type: token.TokenType_OperatorGt, Phrases: [][]string{
+		{"had", " ", "more", " ", "than"},
+		{"has", " ", "more", " ", "than"},
+		{"were", " ", "more", " ", "than"},
+		{"was", " ", "more", " ", "than"},
+		{"is", " ", "greater", " ", "than"},
+		{"was", " ", "greater", " ", "than"},
+		{"were", " ", "greater", " ", "than"},
+	}},
+	{Type: token.TokenType_OperatorLt, Phrases: [][]string{
+		{"had", " ", "less", " ", "than"},
+		{"has", " ", "less", " ", "than"},
+		{"is", " ", "less", " ", "than"},
+		{"was", " ", "less", " ", "than"},
+		{"were", " ", "less", " ", "than"},
+	}},
+	{Type: token.TokenType_OperatorNeq, Phrases: [][]string{
+		{"wasn", "'", "t", " ", "equal", " ", "to"},
+		{"isn", "'", "t", " ", "equal", " ", "to"},
+		{"weren", "'", "t", " ", "equal", " ", "to"},
+		{"had", "'", "t"},
+		{"has", "'", "t"},
+		{"isn", "'", "t"},
+		{"wasn", "'", "t"},
+		{"weren", "'", "t"},
+		{"had", " ", "not"},
+		{"has", " ", "not"},
+		{"is", " ", "not"},
+		{"was", " ", "not"},
+		{"were", " ", "not"},
+	}},
+	{Type: token.TokenType_OperatorEq, Phrases: [][]string{
+		{"is", " ", "equal", " ", "to"},
+		{"was", " ", "equal", " ", "to"},
+		{"were", " ", "equal", " ", "to"},
+		{"is"},
+		{"was"},
+		{"were"},
+		{"had"},
+		{"has"},
+		{"likes"},
+		{"like"},
+	}},
+
+	{Type: token.TokenType_UnaryIncrementPrefix, Phrases: [][]string{
+		{"There", " ", "was", " ", "one", " ", "more"},
+	}},
+	{Type: token.TokenType_UnaryIncrementPostfix, Phrases: [][]string{
+		{"got", " ", "one", " ", "more"},
+	}},
+	{Type: token.TokenType_UnaryDecrementPrefix, Phrases: [][]string{
+		{"There", " ", "was", " ", "one", " ", "less"},
+	}},
+	{Type: token.TokenType_UnaryDecrementPostfix, Phrases: [][]string{
+		{"got", " ", "one", " ", "less"},
+	}},
+
+	{Type: token.TokenType_KeywordReturn, Phrases: [][]string{
+		{"Then", " ", "you", " ", "get"},
+	}},
+	{Type: token.TokenType_KeywordConst, Phrases: [][]string{
+		{"always"},
+	}},
+	{Type: token.TokenType_KeywordThen, Phrases: [][]string{
+		{"then"},
+	}},
+	{Type: token.TokenType_KeywordAnd, Phrases: [][]string{
+		{"and"},
+	}},
+	{Type: token.TokenType_KeywordOr, Phrases: [][]string{
+		{"or"},
+	}},
+	{Type: token.TokenType_KeywordOf, Phrases: [][]string{
+		{"of"},
+	}},
+	{Type: token.TokenType_KeywordIn, Phrases: [][]string{
+		{"in"},
+	}},
+	{Type: token.TokenType_KeywordFrom, Phrases: [][]string{
+		{"from"},
+	}},
+	{Type: token.TokenType_KeywordTo, Phrases: [][]string{
+		{"to"},
+	}},
+}
+
+// Returns the amount of tokens that make up the first phrase of the keyword
+// that the tokens start with, or 0 if there is none.
+func CheckKeyword(tokens *queue.Queue[*token.Token], keyword Keyword) int {
+	for _, phrase := range keyword.Phrases {
+		if utilities.CheckTokenSequence(tokens, phrase) {
+			return len(phrase)
+		}
+	}
+
+	return 0
+}
+
+// Returns every phrase of the keyword as text, e.g. "That's all about"
+func (k Keyword) Text() []string {
+	phrases := make([]string, 0, len(k.Phrases))
+	for _, phrase := range k.Phrases {
+		phrases = append(phrases, strings.Join(phrase, ""))
+	}
+	return phrases
+}
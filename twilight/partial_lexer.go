@@ -9,7 +9,7 @@ import (
 	"git.jaezmien.com/Jaezmien/fim/twilight/utilities"
 )
 
-var splittable_runes = [...]rune{'.', '!', '?', ':', ',', '(', ')', '"', '\'', ' ', '\t', '\\', '\n'}
+var splittable_runes = [...]rune{'.', '!', '?', ':', ',', '(', ')', '"', '\'', ' ', '\t', '\\', '\r', '\n'}
 
 // Split the source string into a queue separated by a splittable rune
 // This extra step is needed to handle the multi-word tokens that FiM++ uses.
@@ -66,9 +66,20 @@ func createPartialTokens(source string) *queue.Queue[*token.Token] {
 }
 
 // Merge basic tokens that are split across multiple partial tokens.
+// Indentation at the start of a line is removed.
 //
 // e.g.: '0' '.' '0' becomes '0.0'
 func mergePartialTokens(tokens *queue.Queue[*token.Token]) *queue.Queue[*token.Token] {
+	return mergePartialTokensWith(tokens, false)
+}
+
+// Merge basic tokens that are split across multiple partial tokens,
+// while keeping the indentation at the start of a line.
+func mergeLosslessPartialTokens(tokens *queue.Queue[*token.Token]) *queue.Queue[*token.Token] {
+	return mergePartialTokensWith(tokens, true)
+}
+
+func mergePartialTokensWith(tokens *queue.Queue[*token.Token], keepIndentation bool) *queue.Queue[*token.Token] {
 	l := queue.New[*token.Token]()
 
 	partialTokensProcessor := []struct {
@@ -82,7 +93,7 @@ func mergePartialTokens(tokens *queue.Queue[*token.Token]) *queue.Queue[*token.T
 
 	newline := false
 	for tokens.Len() > 0 {
-		if newline && utilities.IsIndentString(tokens.First().Value.Value) {
+		if newline && !keepIndentation && utilities.IsIndentString(tokens.First().Value.Value) {
 			tokens.Dequeue()
 			continue
 		}
@@ -0,0 +1,94 @@
+package twilight
+
+import (
+	"slices"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/twilight/parsers"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// Token types of the keywords that start a line, and are therefore likely to
+// be mistyped into an identifier.
+var statementKeywordTypes = []token.TokenType{
+	token.TokenType_ReportHeader,
+	token.TokenType_ReportFooter,
+	token.TokenType_FunctionMain,
+	token.TokenType_FunctionHeader,
+	token.TokenType_FunctionFooter,
+	token.TokenType_Print,
+	token.TokenType_PrintNewline,
+	token.TokenType_Prompt,
+	token.TokenType_FileReadLines,
+	token.TokenType_FileRead,
+	token.TokenType_FileWrite,
+	token.TokenType_FileAppend,
+	token.TokenType_EnvironmentRead,
+	token.TokenType_FunctionCall,
+	token.TokenType_Declaration,
+	token.TokenType_IfClause,
+	token.TokenType_ElseClause,
+	token.TokenType_IfEndClause,
+	token.TokenType_WhileClause,
+	token.TokenType_ForEveryClause,
+	token.TokenType_KeywordStatementEnd,
+	token.TokenType_Assert,
+	token.TokenType_UnaryIncrementPrefix,
+	token.TokenType_UnaryDecrementPrefix,
+	token.TokenType_KeywordReturn,
+}
+
+// The phrases of every keyword that starts a line, without their trailing punctuation.
+var keywordPhrases = func() []string {
+	phrases := make([]string, 0)
+	for _, keyword := range parsers.Keywords {
+		if !slices.Contains(statementKeywordTypes, keyword.Type) {
+			continue
+		}
+
+		for _, phrase := range keyword.Text() {
+			phrases = append(phrases, strings.TrimRight(phrase, ":,"))
+		}
+	}
+	return phrases
+}()
+
+// Returns the keyword phrase that the start of an identifier was most likely
+// supposed to be.
+//
+// A phrase is only suggested if at most a quarter of it was mistyped, so
+// short phrases such as 'If' are never suggested.
+//
+// e.g.: 'Did you knew that Spike' suggests 'Did you know that'
+func SuggestKeyword(value string) (string, bool) {
+	words := strings.Fields(value)
+
+	closest := ""
+	closestDistance := -1
+	for _, phrase := range keywordPhrases {
+		phraseWords := strings.Fields(phrase)
+		if len(words) < len(phraseWords) {
+			continue
+		}
+
+		prefix := strings.Join(words[:len(phraseWords)], " ")
+		if prefix == phrase {
+			continue
+		}
+
+		distance := luna.EditDistance(prefix, phrase)
+		if distance*4 > len(phrase) {
+			continue
+		}
+
+		// The phrases are compared by how much of them was mistyped
+		if closestDistance == -1 || distance*len(closest) < closestDistance*len(phrase) {
+			closest = phrase
+			closestDistance = distance
+		}
+	}
+
+	return closest, closest != ""
+}
@@ -1,6 +1,8 @@
 package twilight
 
 import (
+	"strings"
+
 	"git.jaezmien.com/Jaezmien/fim/luna/queue"
 	"git.jaezmien.com/Jaezmien/fim/twilight/token"
 )
@@ -12,10 +14,46 @@ func Parse(source string) []*token.Token {
 	t = mergePartialTokens(t)
 
 	t = createTokens(t)
+	if strings.HasPrefix(source, "#!") {
+		t = mergeShebang(t)
+	}
 	t = mergeMultiTokens(t)
 	t = smartIdentifierTokens(t)
 	t = mergeIdentifiers(t)
+	t = mergePostscripts(t)
 	t = cleanTokens(t)
 
 	return t.Flatten()
 }
+
+// Parses the source string into a queue of tokens, while keeping the newline,
+// whitespace and comment tokens that Parse would otherwise remove.
+//
+// The tokens are lossless: every character of the source belongs to exactly
+// one token, in order, so Print reproduces the source.
+func ParseWithTrivia(source string) []*token.Token {
+	var t *queue.Queue[*token.Token]
+	t = createPartialTokens(source)
+	t = mergeLosslessPartialTokens(t)
+
+	t = createTokens(t)
+	if strings.HasPrefix(source, "#!") {
+		t = mergeShebang(t)
+	}
+	t = mergeMultiTokens(t)
+	t = smartIdentifierTokens(t)
+	t = mergeIdentifiers(t)
+	t = mergePostscripts(t)
+
+	return t.Flatten()
+}
+
+// Join the values of the tokens back into source code.
+func Print(tokens []*token.Token) string {
+	sb := strings.Builder{}
+	for _, t := range tokens {
+		sb.WriteString(t.Value)
+	}
+
+	return sb.String()
+}
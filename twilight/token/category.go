@@ -0,0 +1,65 @@
+package token
+
+// A broad group of token types, used to highlight the source code.
+type TokenCategory uint
+
+const (
+	// Whitespace, newlines, punctuation and anything else that is not highlighted
+	TokenCategory_None TokenCategory = iota
+
+	TokenCategory_Keyword
+	TokenCategory_Type
+	TokenCategory_Literal
+	TokenCategory_Comment
+	TokenCategory_Identifier
+	TokenCategory_Operator
+)
+
+var tokenCategoryFriendlyName = map[TokenCategory]string{
+	TokenCategory_None:       "",
+	TokenCategory_Keyword:    "keyword",
+	TokenCategory_Type:       "type",
+	TokenCategory_Literal:    "literal",
+	TokenCategory_Comment:    "comment",
+	TokenCategory_Identifier: "identifier",
+	TokenCategory_Operator:   "operator",
+}
+
+func (c TokenCategory) String() string {
+	return tokenCategoryFriendlyName[c]
+}
+
+// Returns the category that the token type belongs to
+func (t TokenType) Category() TokenCategory {
+	switch t {
+	case TokenType_CommentParen, TokenType_CommentPostScript, TokenType_CommentShebang:
+		return TokenCategory_Comment
+
+	case TokenType_String, TokenType_Character, TokenType_Number, TokenType_Boolean, TokenType_Null:
+		return TokenCategory_Literal
+
+	case TokenType_TypeString, TokenType_TypeChar, TokenType_TypeNumber, TokenType_TypeBoolean,
+		TokenType_TypeStringArray, TokenType_TypeNumberArray, TokenType_TypeBooleanArray:
+		return TokenCategory_Type
+
+	case TokenType_OperatorEq, TokenType_OperatorNeq,
+		TokenType_OperatorGt, TokenType_OperatorGte, TokenType_OperatorLt, TokenType_OperatorLte,
+		TokenType_UnaryNot,
+		TokenType_OperatorAddInfix, TokenType_OperatorAddPrefix, TokenType_UnaryIncrementPrefix, TokenType_UnaryIncrementPostfix,
+		TokenType_OperatorSubInfix, TokenType_OperatorSubPrefix, TokenType_UnaryDecrementPrefix, TokenType_UnaryDecrementPostfix,
+		TokenType_OperatorMulInfix, TokenType_OperatorMulPrefix,
+		TokenType_OperatorDivInfix, TokenType_OperatorDivPrefix,
+		TokenType_OperatorModInfix, TokenType_OperatorModPrefix,
+		TokenType_KeywordOr, TokenType_KeywordAnd:
+		return TokenCategory_Operator
+
+	case TokenType_Identifier:
+		return TokenCategory_Identifier
+
+	case TokenType_Unknown, TokenType_Punctuation, TokenType_NewLine, TokenType_Whitespace, TokenType_EndOfFile:
+		return TokenCategory_None
+
+	default:
+		return TokenCategory_Keyword
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"git.jaezmien.com/Jaezmien/fim/luna/errors"
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
 )
 
 type TokenType uint
@@ -100,6 +101,19 @@ const (
 	TokenType_WhileClause
 
 	TokenType_ForEveryClause
+
+	TokenType_Assert
+
+	TokenType_MoreInput
+
+	TokenType_FileRead
+	TokenType_FileReadLines
+	TokenType_FileWrite
+	TokenType_FileAppend
+
+	TokenType_EnvironmentRead
+
+	TokenType_CommentShebang
 )
 
 var tokenTypeFriendlyName = map[TokenType]string{
@@ -113,6 +127,7 @@ var tokenTypeFriendlyName = map[TokenType]string{
 
 	TokenType_CommentParen:      "COMMENT",
 	TokenType_CommentPostScript: "COMMENT",
+	TokenType_CommentShebang:    "COMMENT",
 
 	TokenType_String:    "LITERAL(STRING)",
 	TokenType_Character: "LITERAL(CHARACTER)",
@@ -194,6 +209,27 @@ var tokenTypeFriendlyName = map[TokenType]string{
 	TokenType_WhileClause: "WHILE",
 
 	TokenType_ForEveryClause: "FOREVERY",
+
+	TokenType_Assert: "ASSERT",
+
+	TokenType_MoreInput: "MORE_INPUT",
+
+	TokenType_FileRead:      "FILE(READ)",
+	TokenType_FileReadLines: "FILE(READ_LINES)",
+	TokenType_FileWrite:     "FILE(WRITE)",
+	TokenType_FileAppend:    "FILE(APPEND)",
+
+	TokenType_EnvironmentRead: "ENVIRONMENT(READ)",
+}
+
+// Checks if the token starts a file statement
+func (t TokenType) IsFileMethod() bool {
+	switch t {
+	case TokenType_FileRead, TokenType_FileReadLines, TokenType_FileWrite, TokenType_FileAppend:
+		return true
+	default:
+		return false
+	}
 }
 
 func (t TokenType) String() string {
@@ -215,6 +251,11 @@ func (t *Token) Append(token *Token) {
 	t.Length += token.Length
 }
 
-func (t *Token) CreateError(msg string, source string) error {
-	return errors.NewParseError(msg, source, t.Start)
+func (t *Token) CreateError(msg string, file *luna.SourceFile) error {
+	return errors.NewParseError(msg, file, t.Start)
+}
+
+// Returns the start and end positions of the token.
+func (t *Token) Range(file *luna.SourceFile) luna.Range {
+	return file.Range(t.Start, t.Length)
 }
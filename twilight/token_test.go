@@ -1,6 +1,9 @@
 package twilight
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"git.jaezmien.com/Jaezmien/fim/twilight/token"
@@ -45,6 +48,77 @@ func TestTokenizer(t *testing.T) {
 
 		CheckTokens(t, tokens, checks)
 	})
+
+	t.Run("more input condition", func(t *testing.T) {
+		tokens := Parse("As long as there is more to read,")
+
+		checks := []struct {
+			tokenType     token.TokenType
+			expectedValue string
+		}{
+			{tokenType: token.TokenType_WhileClause, expectedValue: "As long as"},
+			{tokenType: token.TokenType_MoreInput, expectedValue: "there is more to read"},
+			{tokenType: token.TokenType_Punctuation, expectedValue: ","},
+			{tokenType: token.TokenType_EndOfFile, expectedValue: ""},
+		}
+
+		CheckTokens(t, tokens, checks)
+	})
+	t.Run("shebang", func(t *testing.T) {
+		tokens := Parse("#!/usr/bin/env fim run\nDear Princess Celestia: Hello!")
+
+		checks := []struct {
+			tokenType     token.TokenType
+			expectedValue string
+		}{
+			{tokenType: token.TokenType_ReportHeader, expectedValue: "Dear Princess Celestia:"},
+			{tokenType: token.TokenType_Identifier, expectedValue: "Hello"},
+			{tokenType: token.TokenType_Punctuation, expectedValue: "!"},
+			{tokenType: token.TokenType_EndOfFile, expectedValue: ""},
+		}
+
+		CheckTokens(t, tokens, checks)
+	})
+	t.Run("crlf line endings", func(t *testing.T) {
+		tokens := Parse("Dear Princess Celestia: Hello!\r\nI said Spike!\r\n")
+
+		checks := []struct {
+			tokenType     token.TokenType
+			expectedValue string
+		}{
+			{tokenType: token.TokenType_ReportHeader, expectedValue: "Dear Princess Celestia:"},
+			{tokenType: token.TokenType_Identifier, expectedValue: "Hello"},
+			{tokenType: token.TokenType_Punctuation, expectedValue: "!"},
+			{tokenType: token.TokenType_PrintNewline, expectedValue: "I said"},
+			{tokenType: token.TokenType_Identifier, expectedValue: "Spike"},
+			{tokenType: token.TokenType_Punctuation, expectedValue: "!"},
+			{tokenType: token.TokenType_EndOfFile, expectedValue: ""},
+		}
+
+		CheckTokens(t, tokens, checks)
+	})
+	t.Run("file statements", func(t *testing.T) {
+		tokens := Parse("I studied every line of Lines from \"story.txt\". I appended Line to \"story.txt\".")
+
+		checks := []struct {
+			tokenType     token.TokenType
+			expectedValue string
+		}{
+			{tokenType: token.TokenType_FileReadLines, expectedValue: "I studied every line of"},
+			{tokenType: token.TokenType_Identifier, expectedValue: "Lines"},
+			{tokenType: token.TokenType_KeywordFrom, expectedValue: "from"},
+			{tokenType: token.TokenType_String, expectedValue: "\"story.txt\""},
+			{tokenType: token.TokenType_Punctuation, expectedValue: "."},
+			{tokenType: token.TokenType_FileAppend, expectedValue: "I appended"},
+			{tokenType: token.TokenType_Identifier, expectedValue: "Line"},
+			{tokenType: token.TokenType_KeywordTo, expectedValue: "to"},
+			{tokenType: token.TokenType_String, expectedValue: "\"story.txt\""},
+			{tokenType: token.TokenType_Punctuation, expectedValue: "."},
+			{tokenType: token.TokenType_EndOfFile, expectedValue: ""},
+		}
+
+		CheckTokens(t, tokens, checks)
+	})
 }
 
 func TestPostscript(t *testing.T) {
@@ -121,3 +195,79 @@ func TestPostscript(t *testing.T) {
 		CheckTokens(t, tokens, checks)
 	})
 }
+
+func TestSuggestKeyword(t *testing.T) {
+	t.Run("should suggest a mistyped keyword", func(t *testing.T) {
+		suggestion, ok := SuggestKeyword("Did you knew that Spike")
+		assert.True(t, ok)
+		assert.Equal(t, "Did you know that", suggestion)
+	})
+	t.Run("should suggest the keywords of the lexer", func(t *testing.T) {
+		suggestion, ok := SuggestKeyword("I apended Story")
+		assert.True(t, ok)
+		assert.Equal(t, "I appended", suggestion)
+
+		suggestion, ok = SuggestKeyword("Dear Princes Celestia")
+		assert.True(t, ok)
+		assert.Equal(t, "Dear Princess Celestia", suggestion)
+	})
+	t.Run("should not suggest for unrelated identifiers", func(t *testing.T) {
+		_, ok := SuggestKeyword("Twilight Sparkle")
+		assert.False(t, ok)
+	})
+	t.Run("should not suggest short keywords", func(t *testing.T) {
+		suggestion, _ := SuggestKeyword(`I sayed "Hello"`)
+		assert.NotEqual(t, "If", suggestion)
+
+		_, ok := SuggestKeyword("It")
+		assert.False(t, ok)
+	})
+}
+
+// Checks that every character of the source belongs to exactly one token, in order.
+func CheckLossless(t *testing.T, source string, name string) {
+	tokens := ParseWithTrivia(source)
+
+	assert.Equal(t, source, Print(tokens), "Expected %s to be printed back exactly", name)
+
+	offset := 0
+	for _, token := range tokens {
+		if !assert.Equal(t, offset, token.Start, "Expected token %q of %s to start at %d", token.Value, name, offset) {
+			return
+		}
+		offset += token.Length
+	}
+	assert.Equal(t, len(source), offset, "Expected the tokens of %s to cover the source", name)
+}
+
+func TestLossless(t *testing.T) {
+	t.Run("should keep every character", func(t *testing.T) {
+		sources := []string{
+			"",
+			"Dear Princess Celestia: Lossless!\r\n\tI said  \"Hello\\\" World\"!\r\n",
+			"#!/usr/bin/env fim\n    (A comment) Today I learned how to be lossless!\n",
+			"\t\tP.S. This is a postscript.\n  \nP.P.S. Another one!",
+			"I said 'a' plus 1.5 plus \"unterminated",
+		}
+
+		for _, source := range sources {
+			CheckLossless(t, source, fmt.Sprintf("%q", source))
+		}
+	})
+
+	t.Run("should keep every character of the samples", func(t *testing.T) {
+		files, err := filepath.Glob("../samples/*.fim")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if !assert.NoError(t, err) {
+				continue
+			}
+
+			CheckLossless(t, string(data), file)
+		}
+	})
+}
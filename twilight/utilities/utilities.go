@@ -39,8 +39,11 @@ func IsStringPositiveNumber(value string) bool {
 func IsIndentCharacter(r rune) bool {
 	return r == ' ' || r == '\t'
 }
+
+// Checks if the string is a single whitespace character. A carriage return
+// is whitespace as well, so that CRLF line endings are not read as identifiers.
 func IsIndentString(s string) bool {
-	return len(s) == 1 && IsIndentCharacter(rune(s[0]))
+	return len(s) == 1 && (IsIndentCharacter(rune(s[0])) || s[0] == '\r')
 }
 
 func MergeTokens(q *queue.Queue[*token.Token], amount int) *token.Token {
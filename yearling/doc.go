@@ -0,0 +1,163 @@
+package yearling
+
+import (
+	"fmt"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/spike"
+	"git.jaezmien.com/Jaezmien/fim/spike/nodes"
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+	"git.jaezmien.com/Jaezmien/fim/twilight"
+	"git.jaezmien.com/Jaezmien/fim/twilight/token"
+
+	luna "git.jaezmien.com/Jaezmien/fim/luna/utilities"
+)
+
+// The documentation of a report.
+type Document struct {
+	Title  string
+	Author string
+
+	Paragraphs []Paragraph
+}
+
+// The documentation of a single paragraph.
+type Paragraph struct {
+	Name string
+	// Whether the paragraph is run as a main paragraph ('Today I learned')
+	Main bool
+
+	Parameters []Parameter
+	// The type that the paragraph returns, or UNKNOWN if it does not return anything
+	ReturnType variable.VariableType
+
+	// The text of the comments right above the paragraph, without their parentheses
+	Comments []string
+
+	// Where the paragraph starts in the source
+	Position luna.Position
+}
+
+// Returns the paragraph's signature, e.g. 'quicksort(Apple Bloom ARRAY(NUMBER)) ARRAY(NUMBER)'
+func (p Paragraph) Signature() string {
+	parameters := make([]string, 0, len(p.Parameters))
+	for _, parameter := range p.Parameters {
+		parameters = append(parameters, fmt.Sprintf("%s %s", parameter.Name, parameter.VariableType))
+	}
+
+	signature := fmt.Sprintf("%s(%s)", p.Name, strings.Join(parameters, ", "))
+	if p.ReturnType != variable.UNKNOWN {
+		signature += " " + p.ReturnType.String()
+	}
+	return signature
+}
+
+type Parameter struct {
+	Name         string
+	VariableType variable.VariableType
+}
+
+// Parse the source, and gather the documentation of the report.
+func Generate(source string) (*Document, error) {
+	report, err := spike.CreateReport(twilight.Parse(source), source)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(report, source), nil
+}
+
+// Gather the documentation of a report that was already parsed from the source.
+func New(report *nodes.ReportNode, source string) *Document {
+	document := &Document{
+		Title:      report.Title,
+		Author:     report.Author,
+		Paragraphs: make([]Paragraph, 0),
+	}
+
+	file := luna.NewSourceFile(source)
+	tokens := twilight.ParseWithTrivia(source)
+
+	// The index of each token by where it starts, to find the comments before a paragraph
+	starts := make(map[int]int, len(tokens))
+	for index, t := range tokens {
+		starts[t.Start] = index
+	}
+
+	for _, n := range report.Body {
+		function, ok := n.(*nodes.FunctionNode)
+		if !ok {
+			continue
+		}
+
+		paragraph := Paragraph{
+			Name:       function.Name,
+			Main:       function.Main,
+			Parameters: make([]Parameter, 0, len(function.Parameters)),
+			ReturnType: function.ReturnType,
+			Comments:   make([]string, 0),
+			Position:   file.Position(function.Start),
+		}
+		for _, parameter := range function.Parameters {
+			paragraph.Parameters = append(paragraph.Parameters, Parameter{
+				Name:         parameter.Name,
+				VariableType: parameter.VariableType,
+			})
+		}
+		if index, ok := starts[function.Start]; ok {
+			paragraph.Comments = commentsAbove(tokens, index)
+		}
+
+		document.Paragraphs = append(document.Paragraphs, paragraph)
+	}
+
+	return document
+}
+
+// Collect the parenthetical comments on the lines right above the token at the index.
+//
+// Only lines that have nothing but comments are collected, and a blank line
+// or a line of code stops the search.
+func commentsAbove(tokens []*token.Token, index int) []string {
+	lines := make([][]string, 0)
+
+	i := index - 1
+	for i >= 0 && tokens[i].Type == token.TokenType_Whitespace {
+		i--
+	}
+
+	for i >= 0 && tokens[i].Type == token.TokenType_NewLine {
+		end := i
+		i--
+		for i >= 0 && tokens[i].Type != token.TokenType_NewLine {
+			i--
+		}
+
+		comments := make([]string, 0)
+		found := false
+		for _, t := range tokens[i+1 : end] {
+			if t.Type == token.TokenType_CommentParen {
+				found = true
+				if text := strings.TrimSpace(t.Value[1 : len(t.Value)-1]); text != "" {
+					comments = append(comments, text)
+				}
+				continue
+			}
+			if t.Type != token.TokenType_Whitespace {
+				found = false
+				break
+			}
+		}
+		if !found {
+			break
+		}
+
+		lines = append(lines, comments)
+	}
+
+	result := make([]string, 0)
+	for l := len(lines) - 1; l >= 0; l-- {
+		result = append(result, lines[l]...)
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package yearling
+
+import (
+	"bytes"
+	"testing"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	source := `Dear Princess Celestia: Documents & <Notes>!
+
+	( Not about anything )
+
+	( Counts the apples )
+	( of a basket )   (in a row)
+	I learned how to count using the numbers Basket to get a number.
+		Then you get 0.
+	That's all about how to count.
+
+	(lint:ignore unused-variable)
+	Did you know that Spike is a number?
+	Today I learned how to begin!
+		I said Spike!
+	That's all about how to begin.
+
+	Did you know that Rarity is a word? (Not about the paragraph)
+	I learned how to *wait*.
+	That's all about how to *wait*.
+
+	Your faithful student, Twilight Sparkle.`
+
+	document, err := Generate(source)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("should gather the report", func(t *testing.T) {
+		assert.Equal(t, "Documents & <Notes>", document.Title)
+		assert.Equal(t, "Twilight Sparkle", document.Author)
+		assert.Len(t, document.Paragraphs, 3)
+	})
+
+	t.Run("should gather the signature of each paragraph", func(t *testing.T) {
+		count := document.Paragraphs[0]
+		assert.Equal(t, "how to count", count.Name)
+		assert.False(t, count.Main)
+		assert.Equal(t, []Parameter{{Name: "Basket", VariableType: variable.NUMBER_ARRAY}}, count.Parameters)
+		assert.Equal(t, variable.NUMBER, count.ReturnType)
+		assert.Equal(t, 7, count.Position.Line)
+		assert.Equal(t, "how to count(Basket ARRAY(NUMBER)) NUMBER", count.Signature())
+
+		begin := document.Paragraphs[1]
+		assert.True(t, begin.Main)
+		assert.Empty(t, begin.Parameters)
+		assert.Equal(t, variable.UNKNOWN, begin.ReturnType)
+		assert.Equal(t, "how to begin()", begin.Signature())
+	})
+
+	t.Run("should gather the comments right above each paragraph", func(t *testing.T) {
+		assert.Equal(t, []string{"Counts the apples", "of a basket", "in a row"}, document.Paragraphs[0].Comments)
+		assert.Empty(t, document.Paragraphs[1].Comments)
+		assert.Empty(t, document.Paragraphs[2].Comments)
+	})
+
+	t.Run("should write Markdown", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, document.WriteMarkdown(output)) {
+			return
+		}
+
+		assert.Contains(t, output.String(), "# Documents & \\<Notes\\>\n\n*Written by Twilight Sparkle*\n")
+		assert.Contains(t, output.String(), "## how to count\n\n```\nhow to count(Basket ARRAY(NUMBER)) NUMBER\n```\n\nCounts the apples\n")
+		assert.Contains(t, output.String(), "| Basket | ARRAY(NUMBER) |\n\nReturns NUMBER.\n")
+		assert.Contains(t, output.String(), "## how to begin\n\n```\nhow to begin()\n```\n\nRuns as a main paragraph.\n")
+		assert.Contains(t, output.String(), "## how to \\*wait\\*\n")
+	})
+
+	t.Run("should write HTML", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		if !assert.NoError(t, document.WriteHTML(output)) {
+			return
+		}
+
+		assert.Contains(t, output.String(), "<title>Documents &amp; &lt;Notes&gt;</title>")
+		assert.Contains(t, output.String(), "<h2>how to count</h2>")
+		assert.Contains(t, output.String(), "<p>of a basket</p>")
+		assert.Contains(t, output.String(), "<tr><td>Basket</td><td>ARRAY(NUMBER)</td></tr>")
+		assert.Contains(t, output.String(), `<p class="main">Runs as a main paragraph.</p>`)
+	})
+
+	t.Run("should not document a report with errors", func(t *testing.T) {
+		_, err := Generate(`Dear Princess Celestia: Broken!`)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package yearling
+
+import (
+	"html/template"
+	"io"
+)
+
+var htmlTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; color: #222; }
+pre { background: #f4f1fa; padding: 0.5rem 1rem; overflow-x: auto; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.25rem 0.75rem; text-align: left; }
+.author { font-style: italic; }
+.main { color: #6a3d9a; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="author">Written by {{.Author}}</p>
+{{- range .Paragraphs}}
+<section>
+<h2>{{.Name}}</h2>
+<pre><code>{{.Signature}}</code></pre>
+{{- if .Main}}
+<p class="main">Runs as a main paragraph.</p>
+{{- end}}
+{{- range .Comments}}
+<p>{{.}}</p>
+{{- end}}
+{{- if .Parameters}}
+<table>
+<tr><th>Parameter</th><th>Type</th></tr>
+{{- range .Parameters}}
+<tr><td>{{.Name}}</td><td>{{.VariableType}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .ReturnType}}
+<p>Returns {{.ReturnType}}.</p>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// Write the documentation as a standalone HTML page.
+func (d *Document) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, d)
+}
@@ -0,0 +1,61 @@
+package yearling
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"git.jaezmien.com/Jaezmien/fim/spike/variable"
+)
+
+// Characters that would otherwise be read as Markdown syntax.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`|`, `\|`,
+)
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// Write the documentation as Markdown.
+func (d *Document) WriteMarkdown(w io.Writer) error {
+	sb := &strings.Builder{}
+
+	fmt.Fprintf(sb, "# %s\n\n", escapeMarkdown(d.Title))
+	fmt.Fprintf(sb, "*Written by %s*\n", escapeMarkdown(d.Author))
+
+	for _, paragraph := range d.Paragraphs {
+		fmt.Fprintf(sb, "\n## %s\n\n", escapeMarkdown(paragraph.Name))
+		fmt.Fprintf(sb, "```\n%s\n```\n", paragraph.Signature())
+
+		if paragraph.Main {
+			fmt.Fprintf(sb, "\nRuns as a main paragraph.\n")
+		}
+
+		for _, comment := range paragraph.Comments {
+			fmt.Fprintf(sb, "\n%s\n", escapeMarkdown(comment))
+		}
+
+		if len(paragraph.Parameters) > 0 {
+			fmt.Fprintf(sb, "\n| Parameter | Type |\n| :--- | :--- |\n")
+			for _, parameter := range paragraph.Parameters {
+				fmt.Fprintf(sb, "| %s | %s |\n", escapeMarkdown(parameter.Name), parameter.VariableType)
+			}
+		}
+
+		if paragraph.ReturnType != variable.UNKNOWN {
+			fmt.Fprintf(sb, "\nReturns %s.\n", paragraph.ReturnType)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}